@@ -0,0 +1,179 @@
+package gcli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/cliutil/cmdline"
+)
+
+// UserAliasPrefix the key prefix of user alias in the config file. eg: "alias.st = status --short"
+const UserAliasPrefix = "alias."
+
+// AddUserAlias add a user alias(macro) for the app. the expansion is a command line,
+// will be split to args and replace the alias name before dispatch.
+//
+// The registered commands and command aliases take precedence over user aliases.
+//
+// Usage:
+//
+//	app.AddUserAlias("st", "status --short")
+//	app.AddUserAlias("deploy-prod", "deploy --env prod --confirm")
+//	app.AddUserAlias("ra", "remote add") // multi-word command path
+func (app *App) AddUserAlias(name, expansion string) *App {
+	name = strings.TrimSpace(name)
+	if err := checkUserAlias(name); err != nil {
+		panicf("%s", err.Error())
+	}
+
+	expansion = strings.TrimSpace(expansion)
+	if expansion == "" {
+		panicf("the user alias %q expansion cannot be empty", name)
+	}
+
+	if app.userAliases == nil {
+		app.userAliases = make(map[string]string)
+	}
+	app.userAliases[name] = expansion
+	return app
+}
+
+// checkUserAlias check the user alias name is valid: not empty, no whitespace, not start with '-'.
+func checkUserAlias(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t") || name[0] == '-' {
+		return fmt.Errorf("invalid user alias name %q", name)
+	}
+	return nil
+}
+
+// UserAliases get all user aliases. map: alias name => expansion
+func (app *App) UserAliases() map[string]string { return app.userAliases }
+
+// LoadUserAliases load user aliases from a git-style config file. see ParseUserAliases
+func (app *App) LoadUserAliases(file string) error {
+	fh, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	if err = app.ParseUserAliases(fh); err != nil {
+		return fmt.Errorf("load user aliases from %s: %w", file, err)
+	}
+	return nil
+}
+
+// ParseUserAliases parse user aliases from a git-style config contents.
+//
+// Supported formats, value can be quoted:
+//
+//	# comments, line starts with '#' or ';'
+//	alias.st = "status --short"
+//	alias.deploy-prod = deploy --env prod --confirm
+//
+//	[alias]
+//	co = checkout
+//
+// keys without "alias." prefix outside the "[alias]" section are ignored.
+func (app *App) ParseUserAliases(r io.Reader) error {
+	var section string
+	var lineNo int
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		lineNo++
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		// section. eg: [alias]
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return fmt.Errorf("line %d: invalid section %q", lineNo, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: invalid alias line %q, must be 'name = value'", lineNo, line)
+		}
+
+		key = strings.TrimSpace(key)
+		if section == "alias" {
+			key = strings.TrimPrefix(key, UserAliasPrefix)
+		} else if strings.HasPrefix(key, UserAliasPrefix) && section == "" {
+			key = key[len(UserAliasPrefix):]
+		} else {
+			continue
+		}
+
+		val = strings.TrimSpace(val)
+		if n := len(val); n > 1 && (val[0] == '"' || val[0] == '\'') && val[n-1] == val[0] {
+			if val[0] == '\'' {
+				val = val[1 : n-1]
+			} else if uv, err := strconv.Unquote(val); err == nil {
+				val = uv
+			} else {
+				return fmt.Errorf("line %d: invalid quoted value %s", lineNo, val)
+			}
+		}
+
+		if err := checkUserAlias(key); err != nil {
+			return fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if val == "" {
+			return fmt.Errorf("line %d: invalid alias %q", lineNo, line)
+		}
+		app.AddUserAlias(key, val)
+	}
+
+	return s.Err()
+}
+
+// isUserAlias check the name is a user alias and is not a command or command alias.
+func (app *App) isUserAlias(name string) bool {
+	if _, ok := app.userAliases[name]; !ok {
+		return false
+	}
+	return name != HelpCommand && !app.IsCommand(app.ResolveAlias(name))
+}
+
+// expandUserAlias expand the user alias on the first arg. the expansion can be another user alias.
+//
+// eg: "st -v" -> "status --short -v"
+func (app *App) expandUserAlias(args []string) ([]string, error) {
+	var chain []string
+	for len(args) > 0 && app.isUserAlias(args[0]) {
+		name := args[0]
+		if arrutil.StringsHas(chain, name) {
+			return nil, fmt.Errorf("user alias recursion detected: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+		chain = append(chain, name)
+
+		expanded := cmdline.NewParser(app.userAliases[name]).Parse()
+		app.Debugf("expand the user alias %q to: %v", name, expanded)
+		args = append(expanded, args[1:]...)
+	}
+	return args, nil
+}
+
+// userAliasNames get sorted visible user alias names
+func (app *App) userAliasNames() []string {
+	names := make([]string, 0, len(app.userAliases))
+	for name := range app.userAliases {
+		if app.isUserAlias(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,7 +1,6 @@
 package gcli
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,6 +8,7 @@ import (
 	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3/gevent"
 	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/gcli/v3/internal/helper"
 	"github.com/gookit/goutil/cflag"
 	"github.com/gookit/goutil/cliutil/cmdline"
@@ -46,8 +46,8 @@ type App struct {
 	// AppConfig
 
 	fs *Flags
-	// per-app parse/run state (help/version/completion). each App owns its own,
-	// so concurrent App instances don't share it. global config is in gOpts.
+	// per-app parse/run state (help/version/completion) and config(verbose/strict/enhanceShort).
+	// each App owns its own, so concurrent App instances don't share it.
 	opts *AppOptions
 
 	// Name app name
@@ -58,7 +58,15 @@ type App struct {
 	Func func(app *App, args []string) error
 
 	// middles 应用级中间件: 对所有命令生效, 在命令自身中间件与主函数之前依次执行。
-	middles HandlersChain
+	middles []MiddleFunc
+	// plugins config for discover external plugin commands. see EnablePlugins
+	plugins *pluginConfig
+	// userAliases user defined aliases(macros). see AddUserAlias
+	userAliases map[string]string
+	// pager enabled for display long help. see EnablePager
+	pager bool
+	// logOpts the --log-format and --log-level options are enabled. see EnableLogOpts
+	logOpts bool
 
 	// ExitOnEnd call os.Exit on running end
 	// ExitOnEnd bool
@@ -95,7 +103,7 @@ func New(fns ...func(app *App)) *App {
 //	// Or with a config func
 //	NewApp(func(a *App) {
 //		// do something before init ....
-//		a.Hooks[gevent.OnAppInitAfter] = func () {}
+//		a.On(gevent.OnAppInitAfter, func(ctx *gcli.HookCtx) bool { return false })
 //	})
 func NewApp(fns ...func(app *App)) *App {
 	app := &App{
@@ -112,17 +120,20 @@ func NewApp(fns ...func(app *App)) *App {
 		opt.DisableReorderArgs = true
 	})
 
-	Logf(VerbCrazy, "create a new cli application, and create base ")
-
 	// init
 	app.base = newBase()
-	// 每-App 独立的解析/运行状态(help/version/completion)，避免并发多 App 互相串扰。
-	// 进程级全局配置(verbose/strict/enhanceShort)仍走包级 gOpts。
+	// 每-App 独立的解析/运行状态(help/version/completion)与配置(verbose/strict/enhanceShort)，
+	// 避免并发多 App 互相串扰。未单独设置的配置继承包级 gOpts 的进程默认值。
 	app.opts = newAppOptions()
+	app.Logf(VerbCrazy, "create a new cli application, and create base ")
 
 	// set a default value
-	app.Version = "0.1.0-dev"
-	app.base.Ctx = gCtx
+	app.Version = defaultAppVersion
+	if BuildVersion != "" {
+		app.Version = BuildVersion
+	}
+	// 每个 App 独立的 Context, 不再共享包级 gCtx
+	app.base.Ctx = NewCtx().InitCtx()
 
 	for _, fn := range fns {
 		fn(app)
@@ -169,15 +180,15 @@ func (app *App) initialize() {
 	if !app.completionMode {
 		app.Fire(gevent.OnAppInitBefore, nil)
 	}
-	Logf(VerbCrazy, "initialize the cli application")
+	app.Logf(VerbCrazy, "initialize the cli application")
 
 	// init some info
 	app.initHelpReplacer()
 	app.bindAppOpts()
 
-	// add default error handler.
+	// add default error handler. use min priority, user listeners can return True to skip it.
 	if !app.HasHook(gevent.OnAppRunError) {
-		app.On(gevent.OnAppRunError, defaultErrHandler)
+		app.On(gevent.OnAppRunError, defaultErrHandler, HookPriorityMin)
 	}
 
 	if !app.completionMode {
@@ -187,11 +198,16 @@ func (app *App) initialize() {
 
 // binding app options
 func (app *App) bindAppOpts() {
-	Logf(VerbDebug, "will begin binding app global options")
+	app.Logf(VerbDebug, "will begin binding app global options")
 	// global options flag
 	fs := app.fs
 	app.Fire(gevent.OnAppBindOptsBefore, nil)
 
+	// warn on deprecated global option or old option name is used.
+	fs.OnDeprecated(func(name, msg string) {
+		warnDeprecatedOpt(app.opts, app.Name, name, msg)
+	})
+
 	// binding global options
 	app.opts.bindingOpts(fs, gOpts)
 	// add more ...
@@ -206,7 +222,7 @@ func (app *App) bindAppOpts() {
 	// 非隐藏, 会出现在帮助信息中, 用户无需注册 genac 命令即可使用。
 	fs.StrVar(&app.opts.genCompletion, &gflag.CliOpt{
 		Name: "gen-completion",
-		Desc: "generate completion script for shell(bash/zsh/pwsh)",
+		Desc: gi18n.T("opt.genCompletion"),
 	})
 
 	// support binding custom global options
@@ -254,7 +270,15 @@ func (app *App) AddCommand(c *Command) {
 
 // Use 注册应用级中间件, 对所有命令在其自身中间件与主函数之前执行; 返回 app 以便链式调用。
 func (app *App) Use(handlers ...RunnerFunc) *App {
-	app.middles = append(app.middles, handlers...)
+	for _, fn := range handlers {
+		app.middles = append(app.middles, gateMiddle(fn))
+	}
+	return app
+}
+
+// Around 注册应用级环绕(洋葱模型)中间件, 包裹所有命令的执行, 详见 Command.Around; 返回 app 以便链式调用。
+func (app *App) Around(mws ...MiddleFunc) *App {
+	app.middles = append(app.middles, mws...)
 	return app
 }
 
@@ -306,7 +330,7 @@ func (app *App) doParseOpts(args []string) error {
 		if cflag.IsFlagHelpErr(err) {
 			return nil
 		}
-		Logf(VerbWarn, "parse global options err: <red>%s</>", err.Error())
+		app.Logf(VerbWarn, "parse global options err: <red>%s</>", err.Error())
 	}
 
 	return err
@@ -314,11 +338,11 @@ func (app *App) doParseOpts(args []string) error {
 
 // parseAppOpts parse global options
 func (app *App) parseAppOpts(args []string) (ok bool) {
-	Logf(VerbDebug, "will begin parse app options, input-args: %v", args)
+	app.Logf(VerbDebug, "will begin parse app options, input-args: %v", args)
 
 	// parse global options
 	if err := app.doParseOpts(args); err != nil { // has error.
-		color.Error.Tips(err.Error())
+		app.opts.tips(color.Error, err.Error())
 		return
 	}
 
@@ -326,14 +350,14 @@ func (app *App) parseAppOpts(args []string) (ok bool) {
 	// 补全模式下不触发 opts-parsed 用户钩子(只在非补全模式下 Fire 并判断返回值),
 	// 保持原有"Fire 返回 true 即 return"的语义。
 	if !app.completionMode {
-		evtData := map[string]any{"args": app.args}
+		evtData := map[string]any{gevent.KeyArgs: app.args}
 		if app.Fire(gevent.OnAppOptsParsed, evtData) {
-			Logf(VerbDebug, "stop running on the event %s return True", gevent.OnGlobalOptsParsed)
+			app.Logf(VerbDebug, "stop running on the event %s return True", gevent.OnGlobalOptsParsed)
 			return
 		}
 
 		if app.Fire(gevent.OnGlobalOptsParsed, evtData) {
-			Debugf("stop running on the event %s return True", gevent.OnGlobalOptsParsed)
+			app.Debugf("stop running on the event %s return True", gevent.OnGlobalOptsParsed)
 			return
 		}
 	}
@@ -346,14 +370,20 @@ func (app *App) parseAppOpts(args []string) (ok bool) {
 		return app.showVersionInfo()
 	}
 
-	// disable cli color (global config)
-	if gOpts.NoColor {
+	// apply --log-level, --log-format
+	if app.logOpts {
+		app.applyLogOpts()
+	}
+
+	// disable cli color (app config, or the process default)
+	if app.opts.NoColor() {
 		color.Disable()
 		ccolor.Disable()
 	}
 
-	// verbose 由环境变量 GCLI_VERBOSE / gcli.SetVerbose() 控制(包级 gOpts)。
-	Debugf("app options parsed, verbose: <mgb>%s</>, app-opts: %#v", gOpts.Verbose.String(), app.opts)
+	// verbose 由 app.SetVerbose() 或进程默认值(环境变量 GCLI_VERBOSE / gcli.SetVerbose())控制。
+	verb := app.opts.Verbose()
+	app.Debugf("app options parsed, verbose: <mgb>%s</>, app-opts: %#v", verb.String(), app.opts)
 
 	// TODO show auto-completion for bash/zsh
 	if app.opts.inCompletion {
@@ -364,16 +394,16 @@ func (app *App) parseAppOpts(args []string) (ok bool) {
 	// 静态生成补全脚本: 命中即生成并打印到 stdout, 然后停止后续运行(退出)。
 	if app.opts.genCompletion != "" {
 		if shell := app.opts.genCompletion; shell == HelpCommand || shell == "-h" || shell == "--help" {
-			color.Print(app.GenCompletionHelp())
+			app.opts.printf("%s", app.GenCompletionHelp())
 			return
 		}
 
 		script, err := app.GenCompletionScript(app.opts.genCompletion)
 		if err != nil {
-			color.Error.Tips(err.Error())
-			color.Info.Tips("Run %s --gen-completion help for setup guide", app.BinName())
+			app.opts.tips(color.Error, err.Error())
+			app.opts.tips(color.Info, fmt.Sprintf("Run %s --gen-completion help for setup guide", app.BinName()))
 		} else {
-			color.Print(script)
+			app.opts.printf("%s", script)
 		}
 		return
 	}
@@ -390,7 +420,15 @@ func (app *App) parseAppOpts(args []string) (ok bool) {
 //   - parse args
 //   - check global options
 //   - get command name and command args
-func (app *App) prepareRun() (code PrepareState, name string) {
+//
+// err is not nil on the not-found listener report an error. eg: the plugin command run failed.
+func (app *App) prepareRun() (code PrepareState, name string, err error) {
+	// expand user alias. eg: "st" -> "status --short"
+	if app.args, err = app.expandUserAlias(app.args); err != nil {
+		app.opts.tips(color.Error, err.Error())
+		return ERR, "", err
+	}
+
 	// find command name. (pure parse; apply the result here in one place)
 	fc := app.findCommandName(app.args)
 	app.args = fc.args
@@ -412,7 +450,7 @@ func (app *App) prepareRun() (code PrepareState, name string) {
 		}
 
 		app.commandName = name
-		return GOON, name
+		return GOON, name, nil
 	}
 
 	// NotFound: not input name AND not set defaultCommand
@@ -426,19 +464,20 @@ func (app *App) prepareRun() (code PrepareState, name string) {
 	}
 
 	// NotFound: name is not empty, but is not command.
-	Logf(VerbDebug, "input the command is not an registered: %s", name)
-	hookData := map[string]any{"name": name, "raw": app.inputName, "args": app.args}
-
-	// fire events
-	if app.Fire(gevent.OnAppCmdNotFound, hookData) {
-		return
-	}
-	if app.Fire(gevent.OnCmdNotFound, hookData) {
-		return
+	app.Logf(VerbDebug, "input the command is not an registered: %s", name)
+	hookData := map[string]any{gevent.KeyName: name, gevent.KeyRaw: app.inputName, gevent.KeyArgs: app.args}
+
+	// fire events. the listener can report the error by ctx.WithErr(). eg: run plugin command
+	for _, event := range []string{gevent.OnAppCmdNotFound, gevent.OnCmdNotFound} {
+		app.Debugf("trigger the application event: <green>%s</>", event)
+		ctx := newHookCtx(event, nil, hookData).WithApp(app)
+		if app.Hooks.Fire(event, ctx) {
+			return OK, name, ctx.Err()
+		}
 	}
 
 	app.showCommandTips(name)
-	return ERR, name
+	return ERR, name, nil
 }
 
 // foundCmd carries the result of resolving the input args into a command name.
@@ -462,7 +501,7 @@ func (app *App) findCommandName(args []string) foundCmd {
 			if app.IsCommand(name) {
 				return foundCmd{state: Founded, name: name, args: args}
 			}
-			Logf(VerbError, "the default command '<cyan>%s</>' is invalid", name)
+			app.Logf(VerbError, "the default command '<cyan>%s</>' is invalid", name)
 			return foundCmd{state: NotFound, args: args} // invalid, empty name.
 		}
 		return foundCmd{state: NotFound, args: args}
@@ -478,7 +517,7 @@ func (app *App) findCommandName(args []string) foundCmd {
 	rawName := name
 	// check is valid ID/name string.
 	if !helper.IsGoodCmdId(name) {
-		Logf(VerbWarn, "the input command name(%s) string is invalid", name)
+		app.Logf(VerbWarn, "the input command name(%s) string is invalid", name)
 		return foundCmd{state: NotFound, name: name, raw: rawName, args: args[1:]}
 	}
 
@@ -486,14 +525,14 @@ func (app *App) findCommandName(args []string) foundCmd {
 	// Is command ID. eg: "top:sub"
 	if len(nodes) > 1 {
 		name = app.ResolveAlias(nodes[0])
-		Debugf("input(args[0]) is an command ID, expand it. '%s' -> '%s'", rawName, name)
+		app.Debugf("input(args[0]) is an command ID, expand it. '%s' -> '%s'", rawName, name)
 	} else {
 		rName := app.ResolveAlias(name)
 		nodes = splitPath2names(rName) // TIP: alias can be a command ID
 		// Is command ID. eg: "top:sub"
 		if len(nodes) > 1 {
 			name = nodes[0]
-			Debugf("real command is an command ID, expand it. '%s' -> '%s'", rName, name)
+			app.Debugf("real command is an command ID, expand it. '%s' -> '%s'", rName, name)
 		} else {
 			name = rName
 		}
@@ -510,12 +549,12 @@ func (app *App) findCommandName(args []string) foundCmd {
 	// it is an exists command name, or the built-in 'help' command.
 	// NOTE: 'help' 不在 cmdNames 中，由 prepareRun 的 name==HelpCommand 分支处理。
 	if name == HelpCommand || app.IsCommand(name) {
-		Debugf("the raw input command: '<cyan>%s</>'; real name: '<green>%s</>', args: %v", rawName, name, remain)
+		app.Debugf("the raw input command: '<cyan>%s</>'; real name: '<green>%s</>', args: %v", rawName, name, remain)
 		return foundCmd{state: Founded, name: name, raw: rawName, args: remain}
 	}
 
 	// command doesn't exist
-	Logf(VerbInfo, "the input command name '%s' is not exists. nodes: %v", rawName, nodes)
+	app.Logf(VerbInfo, "the input command name '%s' is not exists. nodes: %v", rawName, nodes)
 	return foundCmd{state: NotFound, name: rawName, raw: rawName, args: remain}
 }
 
@@ -549,35 +588,44 @@ func (app *App) Run(args []string) (code int) {
 	// ensure application initialized
 	app.initialize()
 
-	Debugf("will begin run application. input-args: %v", args)
+	// expand '@file' argument files. skip on completion mode, the file may be incomplete.
+	if app.ArgFiles && !app.completionMode {
+		var err error
+		if args, err = expandArgFiles(app.opts, args, 0); err != nil {
+			app.opts.tips(color.Error, err.Error())
+			app.AddError(err)
+			return app.exitOnEnd(ERR.ToInt())
+		}
+	}
+
+	app.Debugf("will begin run application. input-args: %v", args)
 
 	// parse global flags
 	if false == app.parseAppOpts(args) {
 		return app.exitOnEnd(code)
 	}
 
-	Logf(VerbCrazy, "begin run console application, PID: %d", app.Ctx.PID())
-	pCode, name := app.prepareRun()
+	app.Logf(VerbCrazy, "begin run console application, PID: %d", app.Ctx.PID())
+	pCode, name, err := app.prepareRun()
+	if err != nil {
+		app.AddError(err)
+		return app.exitOnEnd(errExitCode(err))
+	}
 	if pCode != GOON {
 		return app.exitOnEnd(int(pCode))
 	}
 
-	app.Fire(gevent.OnAppPrepared, map[string]any{"name": name})
+	app.Fire(gevent.OnAppPrepared, map[string]any{gevent.KeyName: name})
 
 	// do run input command
 	var exCode int
-	var ec errorx.ErrorCoder
-	err := app.doRunCmd(name, app.args)
+	err = app.doRunCmd(name, app.args)
 	if err != nil {
-		if errors.As(err, &ec) {
-			exCode = ec.Code()
-		} else {
-			exCode = ERR.ToInt()
-		}
+		exCode = errExitCode(err)
 		app.AddError(err)
 	}
 
-	Debugf("command '%s' run complete, exit code: %d, error: %v", name, exCode, err)
+	app.Debugf("command '%s' run complete, exit code: %d, error: %v", name, exCode, err)
 	return app.exitOnEnd(exCode)
 }
 
@@ -609,15 +657,15 @@ func (app *App) RunCmd(name string, args []string) error {
 
 func (app *App) doRunCmd(name string, args []string) (err error) {
 	cmd := app.GetCommand(name)
-	app.fireWithCmd(gevent.OnAppRunBefore, cmd, map[string]any{"args": args})
-	Debugf("will run app command '%s' with args: %v", name, args)
+	app.fireWithCmd(gevent.OnAppRunBefore, cmd, map[string]any{gevent.KeyCmd: name, gevent.KeyArgs: args})
+	app.Debugf("will run app command '%s' with args: %v", name, args)
 
 	// do execute command
 	if err = cmd.innerDispatch(args); err != nil {
 		// err = newRunErr(ERR.ToInt(), err) // TODO need warp it?
-		app.Fire(gevent.OnAppRunError, map[string]any{"err": err})
+		app.fireWithCmd(gevent.OnAppRunError, cmd, map[string]any{gevent.KeyCmd: name, gevent.KeyErr: err})
 	} else {
-		app.Fire(gevent.OnAppRunAfter, map[string]any{"cmd": name})
+		app.fireWithCmd(gevent.OnAppRunAfter, cmd, map[string]any{gevent.KeyCmd: name})
 	}
 	return
 }
@@ -626,7 +674,7 @@ func (app *App) doRunFunc(args []string) (code PrepareState) {
 	// do execute command
 	if err := app.Func(app, args); err != nil {
 		code = ERR
-		app.Fire(gevent.OnAppRunError, map[string]any{"err": err})
+		app.Fire(gevent.OnAppRunError, map[string]any{gevent.KeyErr: err})
 	} else {
 		app.Fire(gevent.OnAppRunAfter, nil)
 	}
@@ -651,7 +699,7 @@ func (app *App) Exec(path string, args []string) error {
 		return fmt.Errorf("exec unknown command %q", path)
 	}
 
-	Debugf("manual exec the application command: %q", path)
+	app.Debugf("manual exec the application command: %q", path)
 
 	// parse flags and execute command
 	return cmd.innerExecute(args, false)
@@ -661,13 +709,35 @@ func (app *App) Exec(path string, args []string) error {
  * region T:helper methods
  *************************************************************/
 
-// Opts get the process-level GlobalOpts (verbose/strict/enhanceShort, shared).
-// for the app's own parse state (help/version/completion) use AppOpts().
+// Opts get the process-level default GlobalOpts (shared).
+// for the app's own config and parse state (verbose/strict/help/version) use AppOpts().
 func (app *App) Opts() *GlobalOpts { return gOpts }
 
-// AppOpts get the app's own parse/run state (help/version/completion).
+// AppOpts get the app's own config and parse/run state (verbose/strict/help/version/completion).
 func (app *App) AppOpts() *AppOptions { return app.opts }
 
+// SetVerbose level for the app, not affect other apps. see AppOptions.SetVerbose
+func (app *App) SetVerbose(verbose VerbLevel) { app.opts.SetVerbose(verbose) }
+
+// SetStrictMode for parse flags of the app commands. see AppOptions.SetStrictMode
+func (app *App) SetStrictMode(strict bool) { app.opts.SetStrictMode(strict) }
+
+// SetEnhanceShort level for the app commands. see AppOptions.SetEnhanceShort
+func (app *App) SetEnhanceShort(level uint8) { app.opts.SetEnhanceShort(level) }
+
+// SetNoColor disable the color output for the app. see AppOptions.SetNoColor
+func (app *App) SetNoColor(noColor bool) { app.opts.SetNoColor(noColor) }
+
+// Logf print log message by the verbose level of the app.
+func (app *App) Logf(level VerbLevel, format string, v ...any) {
+	logf(app.opts, level, format, v...)
+}
+
+// Debugf print debug message by the verbose level of the app.
+func (app *App) Debugf(format string, v ...any) {
+	logf(app.opts, VerbDebug, format, v...)
+}
+
 // Flags get. TIP: you can custom binding applicaton options
 //
 // Usage:
@@ -685,8 +755,8 @@ func (app *App) Exit(code int) {
 }
 
 func (app *App) exitOnEnd(code int) int {
-	Debugf("application exit with code: %d", code)
-	app.Fire(gevent.OnAppExit, map[string]any{"code": code})
+	app.Debugf("application exit with code: %d", code)
+	app.Fire(gevent.OnAppExit, map[string]any{gevent.KeyCode: code})
 
 	// if IsGteVerbose(VerbDebug) {
 	// 	app.Infoln("[DEBUG] The Runtime Call Stacks:")
@@ -709,15 +779,15 @@ func (app *App) CommandName() string { return app.commandName }
 // SetDefaultCommand set default command name
 func (app *App) SetDefaultCommand(name string) { app.defaultCommand = name }
 
-// On add hook handler for a hook event
-func (app *App) On(name string, handler HookFunc) {
-	Debugf("register application hook: %s", name)
-	app.Hooks.On(name, handler)
+// On add hook handler for a hook event, returns the listener id. see Hooks.On
+func (app *App) On(name string, handler HookFunc, priority ...int) int {
+	app.Debugf("register application hook: %s", name)
+	return app.Hooks.On(name, handler, priority...)
 }
 
 // fire hook on the app. returns True for stop continue run.
 func (app *App) fireWithCmd(event string, cmd *Command, data map[string]any) bool {
-	Debugf("trigger the application event: <green>%s</>, data: %s", event, maputil.ToString(data))
+	app.Debugf("trigger the application event: <green>%s</>, data: %s", event, maputil.ToString(data))
 
 	ctx := newHookCtx(event, cmd, data).WithApp(app)
 	return app.Hooks.Fire(event, ctx)
@@ -725,7 +795,7 @@ func (app *App) fireWithCmd(event string, cmd *Command, data map[string]any) boo
 
 // Fire hook on the app. returns True for stop continue run.
 func (app *App) Fire(event string, data map[string]any) bool {
-	Debugf("trigger the application event: <green>%s</>, data: %s", event, maputil.ToString(data))
+	app.Debugf("trigger the application event: <green>%s</>, data: %s", event, maputil.ToString(data))
 
 	ctx := newHookCtx(event, nil, data).WithApp(app)
 	return app.Hooks.Fire(event, ctx)
@@ -1,10 +1,15 @@
 package gcli_test
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/gflag"
 	"github.com/gookit/goutil/byteutil"
 	"github.com/gookit/goutil/x/assert"
 )
@@ -42,3 +47,174 @@ func TestApp_AppOpts_perAppIsolation(t *testing.T) {
 	is.True(app1.AppOpts().ShowVersion)
 	is.False(app2.AppOpts().ShowVersion)
 }
+
+// 每-App 配置(verbose/strict/enhanceShort)相互独立, 未设置的继承进程默认值。
+func TestApp_perAppConfig(t *testing.T) {
+	is := assert.New(t)
+	defer gcli.ResetGOpts()
+
+	type testOpts struct{ a, u, x bool }
+	mk := func() (*gcli.App, *testOpts) {
+		opts := &testOpts{}
+		a := gcli.NewApp(gcli.NotExitOnEnd())
+		a.Add(&gcli.Command{
+			Name: "test",
+			Config: func(c *gcli.Command) {
+				c.BoolOpt(&opts.a, "all", "a", false, "a")
+				c.BoolOpt(&opts.u, "upload", "u", false, "u")
+				c.BoolOpt(&opts.x, "extract", "x", false, "x")
+			},
+			Func: func(c *gcli.Command, _ []string) error { return nil },
+		})
+		return a, opts
+	}
+
+	app1, opts1 := mk()
+	app2, opts2 := mk()
+	is.True(app1.Ctx != app2.Ctx)
+	is.True(app1.Ctx != gcli.GCtx())
+
+	// inherit the process defaults
+	gcli.SetVerbose(gcli.VerbWarn)
+	is.Eq(gcli.VerbWarn, app1.AppOpts().Verbose())
+	is.Eq(gcli.VerbWarn, app2.AppOpts().Verbose())
+
+	app1.SetVerbose(gcli.VerbQuiet)
+	app1.SetStrictMode(true)
+	app1.SetEnhanceShort(gcli.EnhanceShortMerge)
+	is.Eq(gcli.VerbQuiet, app1.AppOpts().Verbose())
+	is.True(app1.AppOpts().StrictMode())
+	is.Eq(gcli.EnhanceShortMerge, app1.AppOpts().EnhanceShort())
+	// not affect app2 and the process defaults
+	is.Eq(gcli.VerbWarn, app2.AppOpts().Verbose())
+	is.False(app2.AppOpts().StrictMode())
+	is.Eq(gcli.EnhanceShortNone, app2.AppOpts().EnhanceShort())
+	is.Eq(gcli.VerbWarn, gcli.Verbose())
+	is.False(gcli.StrictMode())
+
+	// only app1 merge the short options
+	is.Eq(0, app1.Run([]string{"test", "-aux"}))
+	is.True(opts1.a && opts1.u && opts1.x)
+	app2.Run([]string{"test", "-aux"})
+	is.False(opts2.a || opts2.u || opts2.x)
+
+	// the app level wins the process default
+	gcli.SetVerbose(gcli.VerbDebug)
+	is.Eq(gcli.VerbQuiet, app1.AppOpts().Verbose())
+
+	app1.AppOpts().InheritDefaults()
+	is.Eq(gcli.VerbDebug, app1.AppOpts().Verbose())
+	is.False(app1.AppOpts().StrictMode())
+}
+
+// Logf/Debugf 的级别通过命令所属的 App 解析
+func TestCommand_Logf_byApp(t *testing.T) {
+	is := assert.New(t)
+	b := byteutil.NewBuffer()
+	color.SetOutput(b)
+	defer color.ResetOptions()
+
+	app := gcli.NewApp(gcli.NotExitOnEnd())
+	app.SetVerbose(gcli.VerbDebug)
+	app.Add(&gcli.Command{
+		Name: "test",
+		Func: func(c *gcli.Command, _ []string) error {
+			c.Debugf("debug message from %s", c.Name)
+			c.Logf(gcli.VerbCrazy, "crazy message")
+			return nil
+		},
+	})
+
+	is.Eq(0, app.Run([]string{"test"}))
+	is.StrContains(b.String(), "debug message from test")
+	is.NotContains(b.String(), "crazy message")
+	// process default level is not changed
+	is.Eq(gcli.VerbError, gcli.Verbose())
+
+	// quiet app: no logs
+	b.Reset()
+	app.SetVerbose(gcli.VerbQuiet)
+	app.Debugf("app debug message")
+	gcli.Debugf("default debug message")
+	is.Empty(b.String())
+}
+
+// app 的 EnhanceShort 只在本次解析时生效, 不写回命令的配置
+func TestApp_enhanceShort_notStick(t *testing.T) {
+	is := assert.New(t)
+
+	var a, u, x bool
+	cmd := &gcli.Command{
+		Name: "test",
+		Config: func(c *gcli.Command) {
+			c.BoolOpt(&a, "all", "a", false, "a")
+			c.BoolOpt(&u, "user", "u", false, "u")
+			c.BoolOpt(&x, "extract", "x", false, "x")
+		},
+		Func: func(c *gcli.Command, _ []string) error { return nil },
+	}
+
+	app := gcli.NewApp(gcli.NotExitOnEnd())
+	app.SetEnhanceShort(gcli.EnhanceShortMerge)
+	app.Add(cmd)
+
+	is.Eq(0, app.Run([]string{"test", "-aux"}))
+	is.True(a && u && x)
+	is.Eq(gcli.EnhanceShortNone, cmd.ParserCfg().EnhanceShort)
+
+	// the level is removed from the app, not kept by the command
+	a, u, x = false, false, false
+	app.SetEnhanceShort(gcli.EnhanceShortNone)
+	app.Run([]string{"test", "-aux"})
+	is.False(a || u || x)
+	is.Eq(gcli.EnhanceShortNone, cmd.ParserCfg().EnhanceShort)
+}
+
+// 每个 App 的输入输出相互独立, 不影响进程的 std streams 和 color 输出
+func TestApp_SetOutput(t *testing.T) {
+	is := assert.New(t)
+	b := byteutil.NewBuffer()
+	color.SetOutput(b)
+	defer color.ResetOptions()
+
+	mk := func() (*gcli.App, *bytes.Buffer, *bytes.Buffer) {
+		var name string
+		a := gcli.NewApp(gcli.NotExitOnEnd())
+		a.Add(&gcli.Command{
+			Name: "greet",
+			Config: func(c *gcli.Command) {
+				c.StrOpt2(&name, "name", "the name", gflag.WithQuestion("your name? "))
+			},
+			Func: func(c *gcli.Command, _ []string) error {
+				_, _ = fmt.Fprintln(c.Out(), "hello", name)
+				_, _ = fmt.Fprintln(c.ErrOut(), "done")
+				return nil
+			},
+		})
+
+		out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+		a.SetOutput(out, errOut)
+		return a, out, errOut
+	}
+
+	app1, out1, errOut1 := mk()
+	app2, out2, _ := mk()
+
+	app1.SetInput(strings.NewReader("tom\n"))
+	is.Eq(0, app1.Run([]string{"greet"}))
+	is.StrContains(out1.String(), "your name? ")
+	is.StrContains(out1.String(), "hello tom\n")
+	is.Eq("done\n", errOut1.String())
+
+	// the error tips are written to the app output
+	is.NotEq(0, app2.Run([]string{"not-exists"}))
+	is.StrContains(out2.String(), "not-exists")
+	is.Empty(b.String())
+
+	// nil for the defaults
+	app1.SetOutput(nil, nil)
+	app1.SetInput(nil)
+	is.Eq(os.Stdout, app1.AppOpts().Out())
+	is.Eq(os.Stderr, app1.AppOpts().ErrOut())
+	is.Eq(os.Stdin, app1.AppOpts().In())
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3"
@@ -736,3 +737,199 @@ func TestApp_Use(t *testing.T) {
 		assert.Eq(t, 2, hits)
 	})
 }
+
+func TestApp_Around(t *testing.T) {
+	defer gcli.ResetGOpts()
+
+	var calls []string
+	app := gcli.NewApp(gcli.NotExitOnEnd())
+	ret := app.Around(func(c *gcli.Command, args []string, next func() error) error {
+		calls = append(calls, "app-around:"+c.Name)
+		err := next()
+		calls = append(calls, "app-around-end")
+		return err
+	})
+	assert.True(t, ret == app)
+	app.Use(func(c *gcli.Command, args []string) error {
+		calls = append(calls, "app-gate")
+		return nil
+	})
+
+	cmd := gcli.NewCommand("c1", "desc").WithFunc(func(c *gcli.Command, args []string) error {
+		calls = append(calls, "func")
+		return nil
+	})
+	cmd.Around(func(c *gcli.Command, args []string, next func() error) error {
+		calls = append(calls, "cmd-around")
+		return next()
+	})
+	app.Add(cmd)
+
+	assert.Eq(t, 0, app.Run([]string{"c1"}))
+	assert.Eq(t, []string{"app-around:c1", "app-gate", "cmd-around", "func", "app-around-end"}, calls)
+}
+
+func TestApp_showHelp_wrapWidth(t *testing.T) {
+	buf := byteutil.NewBuffer()
+	color.SetOutput(buf)
+	defer color.ResetOptions()
+
+	newApp := func() *gcli.App {
+		app := newNotExitApp(func(a *gcli.App) {
+			a.HelpConfig.Width = 50
+		})
+		app.Add(gcli.NewCommand("deploy", "deploy the application to the remote servers, supports multi environments", func(c *gcli.Command) {
+			c.Aliases = []string{"dp"}
+			c.AddSubs(gcli.NewCommand("rollback", "rollback the last deployment on the remote servers"))
+		}))
+		return app
+	}
+
+	assert.Eq(t, 0, newApp().Run([]string{"--help"}))
+	str := color.ClearCode(buf.ResetGet())
+	assert.StrContains(t, str, "  deploy       Deploy the application to the\n               remote servers, supports multi\n               environments (alias: dp)\n")
+	assert.StrContains(t, str, "  -h, --help                Display the help\n                            information\n")
+
+	assert.Eq(t, 0, newApp().Run([]string{"deploy", "--help"}))
+	str = color.ClearCode(buf.ResetGet())
+	assert.StrContains(t, str, "  rollback     Rollback the last deployment on the\n               remote servers\n")
+}
+
+func TestApp_HelpTemplate_custom(t *testing.T) {
+	is := assert.New(t)
+
+	newApp := func() *gcli.App {
+		app := newNotExitApp(func(a *gcli.App) {
+			a.Name = "myapp"
+			a.HelpConfig.Width = -1
+		})
+		app.AddHelpFunc("upper", strings.ToUpper)
+		app.Add(gcli.NewCommand("build", "build the project", func(c *gcli.Command) {
+			var out string
+			c.StrOpt(&out, "output", "o", "", "the output dir")
+			c.AddArg("target", "the build target", true)
+		}))
+		return app
+	}
+
+	t.Run("app template", func(t *testing.T) {
+		app := newApp()
+		err := app.SetHelpTemplate(`{{upper .Name}}:{{range .CmdGroups}}{{range .Cmds}} {{.Name}}{{end}}{{end}};{{range .Opts}} --{{.Name}}{{end}}`)
+		is.NoErr(err)
+
+		s, err := app.RenderHelp()
+		is.NoErr(err)
+		is.Eq("MYAPP: build; --gen-completion --help --version", s)
+	})
+
+	t.Run("command template from fs", func(t *testing.T) {
+		app := newApp()
+		fsys := fstest.MapFS{
+			"tpl/cmd.tpl": {Data: []byte(`{{upper .Name}}{{range .Opts}} -{{join .Shorts ","}}/{{.HelpName}}{{end}}{{range .Args}} <{{.Name}}>{{end}} by {$binName}`)},
+		}
+
+		c := app.GetCommand("build")
+		is.NoErr(c.LoadHelpTemplate(fsys, "tpl/cmd.tpl"))
+		s, err := c.RenderHelp()
+		is.NoErr(err)
+		is.Eq("BUILD -o/--output, -o <target> by "+app.BinName(), s)
+		is.Err(c.LoadHelpTemplate(fsys, "tpl/not-exist.tpl"))
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		app := newApp()
+		err := app.SetHelpTemplate(`{{ .Name `)
+		is.ErrSubMsg(err, "parse help template")
+		err = app.SetHelpTemplate(`{{ notExist .Name }}`)
+		is.ErrSubMsg(err, `function "notExist" not defined`)
+
+		// execute error is returned on run
+		app.HelpTemplate = `{{ .Name.NotField }}`
+		_, err = app.RenderHelp()
+		is.ErrSubMsg(err, "render app help")
+
+		c := app.GetCommand("build")
+		c.HelpTemplate = `{{ .Cmd.NotExist }}`
+		is.ErrSubMsg(c.ShowHelp(), `render command "build" help`)
+	})
+}
+
+func TestApp_i18n_zhCN(t *testing.T) {
+	is := assert.New(t)
+	defer gcli.SetLocale(gcli.Locale())
+	gcli.SetLocale("zh_CN.UTF-8")
+	is.Eq("zh-CN", gcli.Locale())
+
+	app := newNotExitApp(func(a *gcli.App) {
+		a.HelpConfig.Width = -1
+	})
+	app.Add(&gcli.Command{
+		Name:     "serve",
+		Desc:     "start the http server",
+		Help:     "the long help",
+		Examples: "{$fullCmd} --port 8080",
+		I18n: map[string]gcli.CmdText{
+			"zh_CN": {Desc: "启动 http 服务", Help: "详细帮助"},
+		},
+		Config: func(c *gcli.Command) {
+			var port int
+			var host string
+			c.IntOpt(&port, "port", "p", 80, "the listen port")
+			c.StrOpt(&host, "host", "", "", "the listen host;true")
+		},
+		Func: func(c *gcli.Command, _ []string) error { return nil },
+	})
+
+	s, err := app.RenderHelp()
+	is.NoErr(err)
+	is.Contains(s, "用法:")
+	is.Contains(s, "全局选项:")
+	is.Contains(s, "可用命令:")
+	is.Contains(s, "启动 http 服务")
+	is.Contains(s, "显示帮助信息")
+
+	c := app.GetCommand("serve")
+	s, err = c.RenderHelp()
+	is.NoErr(err)
+	is.Contains(s, "启动 http 服务")
+	is.Contains(s, "选项:")
+	is.Contains(s, "默认")
+	is.Contains(s, "示例:")
+	// fallback to the Examples
+	is.Contains(s, "--port 8080")
+	is.Contains(s, "详细帮助")
+
+	// validate error
+	err = app.Exec("serve", nil)
+	is.Err(err)
+	is.Eq("选项 'host' 是必须的", err.Error())
+
+	// custom catalog
+	gcli.AddCatalog("zh-CN", gcli.Catalog{"help.commands": "命令列表"})
+	defer gcli.AddCatalog("zh-CN", gcli.Catalog{"help.commands": "可用命令"})
+	s, err = app.RenderHelp()
+	is.NoErr(err)
+	is.Contains(s, "命令列表:")
+	is.Eq("命令列表", gcli.T("help.commands"))
+}
+
+func TestCommand_LocaleText(t *testing.T) {
+	defer gcli.SetLocale(gcli.Locale())
+
+	c := &gcli.Command{
+		Name: "test",
+		Desc: "the desc",
+		Help: "the help",
+		I18n: map[string]gcli.CmdText{
+			"zh": {Desc: "描述"},
+		},
+	}
+
+	gcli.SetLocale("en")
+	assert.Eq(t, gcli.CmdText{Desc: "the desc", Help: "the help"}, c.LocaleText())
+	assert.Eq(t, "The desc", c.HelpDesc())
+
+	gcli.SetLocale("zh-TW")
+	assert.Eq(t, gcli.CmdText{Desc: "描述", Help: "the help"}, c.LocaleText())
+	assert.Eq(t, "描述", c.HelpDesc())
+}
@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/gookit/color"
 	"github.com/gookit/goutil/arrutil"
@@ -163,6 +164,12 @@ type base struct {
 	HelpConfig HelpConfig
 	// helpVars custom add vars for render help template.
 	HelpVars map[string]any
+	// HelpTemplate custom help template. if empty, will use AppHelpTemplate or CmdHelpTemplate.
+	//
+	// the template data is *HelpData. see SetHelpTemplate, LoadHelpTemplate
+	HelpTemplate string
+	// helpFuncs custom template functions for render help. see AddHelpFunc
+	helpFuncs template.FuncMap
 
 	// Ctx data for command, allow add custom context data.
 	Ctx *Context
@@ -174,6 +181,15 @@ type base struct {
 	ExitOnEnd bool
 	// ExitFunc default is os.Exit
 	ExitFunc func(int)
+	// ArgFiles enable expand '@file' argument files(response files) on run, default is false.
+	//
+	// eg: `app build @args.txt` - the args.txt contents will be expanded as input args.
+	// see expandArgFiles() for the file format. use '@@text' for a literal '@text' arg.
+	//
+	// NOTE: only for App.Run and standalone Command.Run
+	ArgFiles bool
+	// DocMeta extra metadata for generated man pages and markdown docs. see DocMeta
+	DocMeta DocMeta
 
 	// all commands for the group
 	commands map[string]*Command
@@ -323,7 +339,7 @@ func (b *base) addCommand(pName string, c *Command) {
 	}
 
 	if c.IsDisabled() {
-		Debugf("command '%s' has been disabled, skip add", cName)
+		c.Debugf("command '%s' has been disabled, skip add", cName)
 		return
 	}
 
@@ -341,7 +357,7 @@ func (b *base) addCommand(pName string, c *Command) {
 	}
 
 	// add aliases for the command
-	Logf(VerbCrazy, "register command '%s'(parent: %s), aliases: %v", cName, pName, c.Aliases)
+	c.Logf(VerbCrazy, "register command '%s'(parent: %s), aliases: %v", cName, pName, c.Aliases)
 	b.cmdAliases.AddAliases(c.Name, c.Aliases)
 	b.commands[cName] = c
 
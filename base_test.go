@@ -16,6 +16,11 @@ var (
 	buf = new(bytes.Buffer)
 )
 
+func init() {
+	// the tests check the english messages, ignore the locale from env
+	gcli.SetLocale("en")
+}
+
 func newNotExitApp(fns ...func(app *gcli.App)) *gcli.App {
 	cli := gcli.New(fns...)
 	cli.ExitOnEnd = false
@@ -95,7 +100,7 @@ func TestApp_On_CmdNotFound(t *testing.T) {
 	cli.Add(newSimpleCmd())
 
 	fmt.Println("--------- will print command tips ----------")
-	cli.On(gevent.OnCmdNotFound, func(ctx *gcli.HookCtx) bool {
+	id := cli.On(gevent.OnCmdNotFound, func(ctx *gcli.HookCtx) bool {
 		b.WriteString("trigger: " + gevent.OnCmdNotFound)
 		b.WriteString("; command: " + ctx.Str("name"))
 		return false
@@ -105,6 +110,8 @@ func TestApp_On_CmdNotFound(t *testing.T) {
 	assert.Eq(t, "trigger: cmd.not.found; command: top", b.ResetGet())
 
 	fmt.Println("--------- dont print command tips ----------")
+	// listeners are not replaced, remove the previous one.
+	cli.Off(gevent.OnCmdNotFound, id)
 	cli.On(gevent.OnCmdNotFound, func(ctx *gcli.HookCtx) bool {
 		b.WriteString("trigger: " + gevent.OnCmdNotFound)
 		b.WriteString("; command: " + ctx.Str("name"))
@@ -1,6 +1,10 @@
 package builtin
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3"
 	"github.com/gookit/gcli/v3/docgen"
@@ -10,9 +14,10 @@ import (
 var docOpts = &struct {
 	format string
 	output string
+	single bool
 }{}
 
-// GenDoc create a command to export application commands documentation(markdown/man).
+// GenDoc create a command to export application commands documentation(markdown/man/rst/adoc/html).
 //
 // 参照 GenAutoComplete 范式: app 添加后即可 `./cliapp gendoc -f md -o ./docs` 导出文档。
 func GenDoc(fns ...func(c *gcli.Command)) *gcli.Command {
@@ -20,17 +25,21 @@ func GenDoc(fns ...func(c *gcli.Command)) *gcli.Command {
 		Func:    doGenDoc,
 		Name:    "gendoc",
 		Aliases: []string{"gen-doc"},
-		Desc:    "generate documentation(markdown/man) for current application commands",
+		Desc:    "generate documentation(markdown/man/rst/asciidoc/html) for current application commands",
 	}
 
 	c.StrOpt(
 		&docOpts.format, "format", "f", "md",
-		"the documentation format for generated, allow: md, man",
+		"the documentation format for generated, allow: "+strings.Join(docgen.Formats(), ", "),
 	)
 	c.StrOpt(
 		&docOpts.output, "output", "o", "./docs",
 		"the output directory for generated documentation files.",
 	)
+	c.BoolOpt(
+		&docOpts.single, "single", "s", false,
+		"render all commands into one markdown document(<output>/<app>.md), only for md format",
+	)
 
 	for _, fn := range fns {
 		fn(c)
@@ -42,20 +51,35 @@ func doGenDoc(c *gcli.Command, _ []string) error {
 	app := c.App()
 	dir := docOpts.output
 
-	// 按 format 选择渲染器; 非法格式直接返回 error。
-	switch docOpts.format {
-	case "md", "mkdown", "markdown":
-		if err := docgen.MarkdownTree(app, dir); err != nil {
-			return c.NewErrf("generate markdown docs error: %s", err.Error())
-		}
-	case "man":
-		if err := docgen.ManTree(app, dir); err != nil {
-			return c.NewErrf("generate man docs error: %s", err.Error())
-		}
-	default:
-		return c.NewErrf("invalid format %q, allow: md, man", docOpts.format)
+	// 单文档模式: 整个命令树写入一个 markdown 文件
+	if docOpts.single {
+		return genSingleDoc(c, dir)
+	}
+
+	// 按 format 选择已注册的渲染器; 非法格式直接返回 error。
+	if err := docgen.Generate(app, docOpts.format, dir); err != nil {
+		return c.NewErrf("generate %s docs error: %s", docOpts.format, err.Error())
 	}
 
 	color.Success.Printf("\nOK, documentation(%s) generated to: %s\n", docOpts.format, dir)
 	return nil
 }
+
+func genSingleDoc(c *gcli.Command, dir string) error {
+	app := c.App()
+	if r, ok := docgen.Get(docOpts.format); !ok || r != docgen.Markdown {
+		return c.NewErrf("option --single only supports md format, but got %q", docOpts.format)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	file := filepath.Join(dir, app.Name+".md")
+	if err := os.WriteFile(file, []byte(docgen.SingleMarkdown(app, nil)), 0644); err != nil {
+		return err
+	}
+
+	color.Success.Printf("\nOK, single documentation generated to: %s\n", file)
+	return nil
+}
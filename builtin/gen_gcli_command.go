@@ -1,9 +1,473 @@
 package builtin
 
-import "github.com/gookit/gcli/v3"
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
 
-// GenCmdCode command TODO
-var GenCmdCode = &gcli.Command{
-	Name: "gen-cmd",
-	Desc: "quick generate gcli command code",
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/strutil"
+)
+
+//go:embed template/*.tpl
+var codeTplFS embed.FS
+
+// gen command code options
+var genCmdOpts = &struct {
+	desc     string
+	opts     gflag.Strings
+	args     gflag.Strings
+	pkgName  string
+	dir      string
+	mainFile string
+	noTest   bool
+	force    bool
+}{}
+
+// GenCmdCode create the command for generate gcli command code.
+//
+// Usage:
+//
+//	app.Add(builtin.GenCmdCode())
+//	// run: app gen-cmd user:create -d "create a user" --opt name:string:n:the user name --arg files:required,arrayed
+func GenCmdCode(fns ...func(c *gcli.Command)) *gcli.Command {
+	c := &gcli.Command{
+		Func:    doGenCmdCode,
+		Name:    "gen-cmd",
+		Aliases: []string{"gencmd"},
+		Desc:    "quick generate gcli command code and test file",
+		Examples: `{$fullCmd} user:create -d "create a user" --opt name:string:n:the user name --opt age:int::the user age
+  {$fullCmd} serve --opt port:int:p:the listen port --arg root:required:the web root --main ./main.go`,
+		Help: `Option spec:
+  --opt  name:type:shorts:desc    type allow: string, bool, int, int64, uint, uint64,
+                                  float64, duration, strings, ints, bools, kv(map[string]string)
+  --arg  name:flags:desc          flags allow: required, arrayed. eg: "files:required,arrayed"`,
+	}
+
+	c.StrOpt(&genCmdOpts.desc, "desc", "d", "", "the command description")
+	c.VarOpt(&genCmdOpts.opts, "opt", "o", "add an option for the command, format: name:type:shorts:desc")
+	c.VarOpt(&genCmdOpts.args, "arg", "a", "add an argument for the command, format: name:flags:desc")
+	c.StrOpt(&genCmdOpts.pkgName, "package", "p", "", "the package name, default is the dir name")
+	c.StrOpt(&genCmdOpts.dir, "dir", "D", "./cmd", "the output directory for the generated files")
+	c.StrOpt(&genCmdOpts.mainFile, "main", "m", "", "add the command into an exists main.go file")
+	c.BoolOpt(&genCmdOpts.noTest, "no-test", "", false, "do not generate the test file")
+	c.BoolOpt(&genCmdOpts.force, "force", "f", false, "overwrite the exists files")
+	c.AddArg("path", "the command path. eg: 'serve', 'user:create' or 'user create'", true)
+
+	for _, fn := range fns {
+		fn(c)
+	}
+	return c
+}
+
+// GenCmdOpt an option spec for generate command code
+type GenCmdOpt struct {
+	Name   string
+	Shorts string
+	Desc   string
+	// Type the spec type name. eg: string, int, strings
+	Type string
+	// Field the struct field name. eg: "DryRun"
+	Field  string
+	GoType string
+}
+
+// Tag the struct tag value by the named rule. see gflag.TagRuleNamed
+//
+// the '"', '\' and control chars are escaped, it is used in the quoted value of the struct tag.
+func (o *GenCmdOpt) Tag() string {
+	tag := "name=" + o.Name
+	if o.Shorts != "" {
+		tag += ";shorts=" + o.Shorts
+	}
+	if o.Desc != "" {
+		tag += ";desc=" + o.Desc
+	}
+
+	quoted := strconv.Quote(tag)
+	return quoted[1 : len(quoted)-1]
+}
+
+// GenCmdArg an argument spec for generate command code
+type GenCmdArg struct {
+	Name     string
+	Desc     string
+	Required bool
+	Arrayed  bool
+	// Var the go var name. eg: "inputFiles"
+	Var string
+}
+
+// GenCmdData the template data for generate command code
+type GenCmdData struct {
+	Package string
+	// Path the command path. eg: "user create"
+	Path string
+	// Name the command name, last element of the Path.
+	Name string
+	Desc string
+	// VarName the command var name. eg: UserCreateCmd
+	VarName  string
+	FuncName string
+	OptsType string
+	OptsVar  string
+	// StdImports the imports of the standard library. eg: "time"
+	StdImports []string
+	Imports    []string
+	Opts       []*GenCmdOpt
+	Args       []*GenCmdArg
+	// TestArgs the input args for the test exec
+	TestArgs []string
+}
+
+// spec type name => go type
+var genOptTypes = map[string]string{
+	"string":   "string",
+	"bool":     "bool",
+	"int":      "int",
+	"int64":    "int64",
+	"uint":     "uint",
+	"uint64":   "uint64",
+	"float":    "float64",
+	"float64":  "float64",
+	"duration": "time.Duration",
+	"strings":  "[]string",
+	"ints":     "[]int",
+	"bools":    "[]bool",
+	"kv":       "map[string]string",
+}
+
+var (
+	goodNameReg = regexp.MustCompile(`^[a-zA-Z][\w-]*$`)
+	// match the app run call in main file. eg: "app.Run(nil)"
+	appRunReg = regexp.MustCompile(`(?m)^([ \t]*)(\w+)\.Run\(`)
+)
+
+func doGenCmdCode(c *gcli.Command, _ []string) error {
+	opts := genCmdOpts
+	dir := opts.dir
+
+	pkgName := opts.pkgName
+	if pkgName == "" {
+		pkgName = guessPkgName(dir, opts.mainFile)
+	}
+
+	data, err := NewGenCmdData(c.Arg("path").String(), opts.desc, pkgName)
+	if err != nil {
+		return err
+	}
+	if opts.mainFile != "" && strings.ContainsRune(data.Path, ' ') {
+		return c.NewErrf("only a top command can be added into main file, got: %s", data.Path)
+	}
+	if err := data.ParseOpts(opts.opts); err != nil {
+		return err
+	}
+	if err := data.ParseArgs(opts.args); err != nil {
+		return err
+	}
+
+	code, err := data.Render("cmd-code.tpl")
+	if err != nil {
+		return err
+	}
+
+	// eg: "user create" -> "user_create.go"
+	fileName := strings.NewReplacer(" ", "_", "-", "_").Replace(data.Path)
+	files := map[string][]byte{fileName + ".go": code}
+	if !opts.noTest {
+		if files[fileName+"_test.go"], err = data.Render("cmd-test.tpl"); err != nil {
+			return err
+		}
+	}
+
+	for name, src := range files {
+		file := filepath.Join(dir, name)
+		if !opts.force && fsutil.IsFile(file) {
+			return c.NewErrf("the file %s already exists, use --force for overwrite", file)
+		}
+		if err := fsutil.MkParentDir(file); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, src, 0644); err != nil {
+			return err
+		}
+		color.Info.Println("Generated:", file)
+	}
+
+	if opts.mainFile != "" {
+		if err := AddCmdToMain(opts.mainFile, dir, data); err != nil {
+			return err
+		}
+		color.Info.Println("Added into:", opts.mainFile)
+	}
+
+	color.Success.Printf("\nOK, the command '%s' code generated\n", data.Path)
+	return nil
+}
+
+// guessPkgName guess the package name by the output dir.
+// it is "main" on the dir is the same as the main file dir.
+func guessPkgName(dir, mainFile string) string {
+	absDir, _ := filepath.Abs(dir)
+	if mainFile != "" {
+		if mainDir, _ := filepath.Abs(filepath.Dir(mainFile)); mainDir == absDir {
+			return "main"
+		}
+	}
+
+	name := strings.ToLower(filepath.Base(absDir))
+	name = strings.NewReplacer("-", "", "_", "", ".", "").Replace(name)
+	if name == "" || !goodNameReg.MatchString(name) {
+		return "cmd"
+	}
+	return name
+}
+
+// NewGenCmdData create the template data. path eg: "serve", "user:create", "user create"
+func NewGenCmdData(path, desc, pkgName string) (*GenCmdData, error) {
+	names := strings.Fields(strings.ReplaceAll(path, ":", " "))
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the command path cannot be empty")
+	}
+	for _, name := range names {
+		if !goodNameReg.MatchString(name) {
+			return nil, fmt.Errorf("invalid command name %q in the path %q", name, path)
+		}
+	}
+
+	varName := goIdent(strings.Join(names, "-"), true)
+	if desc == "" {
+		desc = "the " + strings.Join(names, " ") + " command"
+	}
+
+	return &GenCmdData{
+		Package:  pkgName,
+		Path:     strings.Join(names, " "),
+		Name:     names[len(names)-1],
+		Desc:     desc,
+		VarName:  varName + "Cmd",
+		FuncName: "run" + varName,
+		OptsType: strutil.LowerFirst(varName) + "Opts",
+		OptsVar:  strutil.LowerFirst(varName) + "Data",
+		Imports:  []string{"github.com/gookit/gcli/v3"},
+	}, nil
+}
+
+// ParseOpts parse the option specs. format: name:type:shorts:desc
+//
+// eg: "name:string:n:the user name", "age:int", "dry-run:bool::run without changes"
+func (d *GenCmdData) ParseOpts(specs []string) error {
+	for _, spec := range specs {
+		// the struct tag is a raw string, cannot contain the backtick
+		if strings.ContainsRune(spec, '`') {
+			return fmt.Errorf("the option spec cannot contain the backtick, got %q", spec)
+		}
+
+		nodes := strings.SplitN(spec, ":", 4)
+		for len(nodes) < 4 {
+			nodes = append(nodes, "")
+		}
+
+		opt := &GenCmdOpt{
+			Name:   strings.TrimSpace(nodes[0]),
+			Type:   strings.TrimSpace(nodes[1]),
+			Shorts: strings.TrimSpace(nodes[2]),
+			// ';' is the separator of the struct tag rule
+			Desc: strings.ReplaceAll(strings.TrimSpace(nodes[3]), ";", ","),
+		}
+		if !goodNameReg.MatchString(opt.Name) {
+			return fmt.Errorf("invalid option name in the spec %q", spec)
+		}
+
+		if opt.Type == "" {
+			opt.Type = "string"
+		}
+		goType, ok := genOptTypes[opt.Type]
+		if !ok {
+			return fmt.Errorf("invalid option type %q in the spec %q", opt.Type, spec)
+		}
+
+		opt.GoType = goType
+		opt.Field = goIdent(opt.Name, true)
+		if goType == "time.Duration" && !arrutil.StringsHas(d.StdImports, "time") {
+			d.StdImports = append(d.StdImports, "time")
+		}
+		d.Opts = append(d.Opts, opt)
+	}
+	return nil
+}
+
+// ParseArgs parse the argument specs. format: name:flags:desc
+//
+// eg: "files:required,arrayed:the input files", "name::the name"
+func (d *GenCmdData) ParseArgs(specs []string) error {
+	for _, spec := range specs {
+		nodes := strings.SplitN(spec, ":", 3)
+		for len(nodes) < 3 {
+			nodes = append(nodes, "")
+		}
+
+		arg := &GenCmdArg{
+			Name: strings.TrimSpace(nodes[0]),
+			Desc: strings.TrimSpace(nodes[2]),
+		}
+		if !goodNameReg.MatchString(arg.Name) {
+			return fmt.Errorf("invalid argument name in the spec %q", spec)
+		}
+
+		for _, flag := range strutil.Split(nodes[1], ",") {
+			switch flag {
+			case "required":
+				arg.Required = true
+			case "arrayed":
+				arg.Arrayed = true
+			default:
+				return fmt.Errorf("invalid argument flag %q in the spec %q", flag, spec)
+			}
+		}
+
+		if arg.Desc == "" {
+			arg.Desc = "the " + arg.Name
+		}
+		arg.Var = goIdent(arg.Name, false)
+
+		// give a value for the required argument on test
+		if arg.Required {
+			d.TestArgs = append(d.TestArgs, arg.Name)
+		}
+		d.Args = append(d.Args, arg)
+	}
+	return nil
+}
+
+// Render the template by name, and format the go code.
+func (d *GenCmdData) Render(tplName string) ([]byte, error) {
+	tpl, err := template.ParseFS(codeTplFS, "template/"+tplName)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, d); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format the generated code(%s) error: %w", tplName, err)
+	}
+	return src, nil
+}
+
+// AddCmdToMain add the generated command into the main file. eg: app.Add(cmd.UserCreateCmd)
+//
+// it inserts before the app.Run() call, and adds the package import if needed.
+func AddCmdToMain(mainFile, cmdDir string, d *GenCmdData) error {
+	bs, err := os.ReadFile(mainFile)
+	if err != nil {
+		return err
+	}
+
+	expr := d.VarName
+	var importPath string
+	if d.Package != "main" {
+		expr = d.Package + "." + d.VarName
+		if importPath, err = goImportPath(cmdDir); err != nil {
+			return err
+		}
+	}
+
+	src := string(bs)
+	if strings.Contains(src, ".Add("+expr+")") {
+		return nil // already added
+	}
+
+	m := appRunReg.FindStringSubmatchIndex(src)
+	if m == nil {
+		return fmt.Errorf("not found the app Run() call in %s", mainFile)
+	}
+
+	indent, appVar := src[m[2]:m[3]], src[m[4]:m[5]]
+	src = src[:m[0]] + indent + appVar + ".Add(" + expr + ")\n" + src[m[0]:]
+	if importPath != "" {
+		src = addGoImport(src, importPath)
+	}
+
+	out, err := format.Source([]byte(src))
+	if err != nil {
+		return fmt.Errorf("format the main file %s error: %w", mainFile, err)
+	}
+	return os.WriteFile(mainFile, out, 0644)
+}
+
+// addGoImport add the import path to the go source, if not exists.
+func addGoImport(src, path string) string {
+	quoted := `"` + path + `"`
+	if strings.Contains(src, quoted) {
+		return src
+	}
+
+	// import ( ... )
+	if pos := strings.Index(src, "import ("); pos >= 0 {
+		end := pos + strings.Index(src[pos:], "\n)")
+		return src[:end] + "\n\t" + quoted + src[end:]
+	}
+
+	// import "fmt"
+	reg := regexp.MustCompile(`(?m)^import\s+("[^"]+")`)
+	if reg.MatchString(src) {
+		return reg.ReplaceAllString(src, "import (\n\t$1\n\t"+quoted+"\n)")
+	}
+
+	// no imports, add after the package clause
+	reg = regexp.MustCompile(`(?m)^package\s+\w+\n`)
+	return reg.ReplaceAllString(src, "$0\nimport "+quoted+"\n")
+}
+
+// goImportPath get the go import path for the dir, by the module path in go.mod
+func goImportPath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	modReg := regexp.MustCompile(`(?m)^module\s+(\S+)`)
+	for cur := absDir; ; cur = filepath.Dir(cur) {
+		if bs, err := os.ReadFile(filepath.Join(cur, "go.mod")); err == nil {
+			m := modReg.FindSubmatch(bs)
+			if m == nil {
+				return "", fmt.Errorf("not found the module path in %s", filepath.Join(cur, "go.mod"))
+			}
+
+			rel, _ := filepath.Rel(cur, absDir)
+			if rel == "." {
+				return string(m[1]), nil
+			}
+			return string(m[1]) + "/" + filepath.ToSlash(rel), nil
+		}
+
+		if filepath.Dir(cur) == cur {
+			return "", fmt.Errorf("not found the go.mod for the dir %s", dir)
+		}
+	}
+}
+
+// goIdent convert the name to go identifier. eg: "dry-run" -> "DryRun"(exported) or "dryRun"
+func goIdent(name string, exported bool) string {
+	name = strutil.CamelCase(strings.ReplaceAll(name, "_", "-"), "-")
+	if exported {
+		return strutil.UpperFirst(name)
+	}
+	return strutil.LowerFirst(name)
 }
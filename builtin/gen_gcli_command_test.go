@@ -0,0 +1,296 @@
+package builtin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestGenCmdData_ParseOpts(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want GenCmdOpt
+		err  string
+	}{
+		{
+			name: "full",
+			spec: "name:string:n:the user name",
+			want: GenCmdOpt{Name: "name", Type: "string", Shorts: "n", Desc: "the user name", Field: "Name", GoType: "string"},
+		},
+		{
+			name: "default type",
+			spec: "dry-run",
+			want: GenCmdOpt{Name: "dry-run", Type: "string", Field: "DryRun", GoType: "string"},
+		},
+		{
+			name: "desc contains colon and semicolon",
+			spec: "tags:kv::the tags; eg: a=b",
+			want: GenCmdOpt{Name: "tags", Type: "kv", Desc: "the tags, eg: a=b", Field: "Tags", GoType: "map[string]string"},
+		},
+		{name: "invalid name", spec: "1name:string", err: `invalid option name in the spec "1name:string"`},
+		{name: "invalid type", spec: "age:int8", err: `invalid option type "int8" in the spec "age:int8"`},
+		{name: "backtick", spec: "name:string::the `name`", err: "the option spec cannot contain the backtick, got \"name:string::the `name`\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &GenCmdData{}
+			err := d.ParseOpts([]string{tt.spec})
+			if tt.err != "" {
+				assert.ErrMsg(t, err, tt.err)
+				return
+			}
+
+			assert.NoErr(t, err)
+			assert.Len(t, d.Opts, 1)
+			assert.Eq(t, tt.want, *d.Opts[0])
+		})
+	}
+
+	// duration type adds the "time" import once
+	d := &GenCmdData{}
+	assert.NoErr(t, d.ParseOpts([]string{"timeout:duration", "wait:duration"}))
+	assert.Eq(t, []string{"time"}, d.StdImports)
+}
+
+func TestGenCmdOpt_Tag(t *testing.T) {
+	tests := []struct {
+		opt  GenCmdOpt
+		want string
+	}{
+		{GenCmdOpt{Name: "name"}, "name=name"},
+		{GenCmdOpt{Name: "name", Shorts: "n", Desc: "the user name"}, "name=name;shorts=n;desc=the user name"},
+		{GenCmdOpt{Name: "msg", Desc: `the "message"`}, `name=msg;desc=the \"message\"`},
+		{GenCmdOpt{Name: "dir", Desc: `the dir. eg: C:\tmp`}, `name=dir;desc=the dir. eg: C:\\tmp`},
+	}
+
+	for _, tt := range tests {
+		assert.Eq(t, tt.want, tt.opt.Tag())
+
+		// the struct tag can be parsed, value is same as the input desc
+		tag := reflect.StructTag(`flag:"` + tt.opt.Tag() + `"`)
+		val, ok := tag.Lookup("flag")
+		assert.True(t, ok)
+		assert.StrContains(t, val, tt.opt.Desc)
+	}
+}
+
+func TestGenCmdData_ParseArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want GenCmdArg
+		err  string
+	}{
+		{
+			name: "full",
+			spec: "files:required,arrayed:the input files",
+			want: GenCmdArg{Name: "files", Desc: "the input files", Required: true, Arrayed: true, Var: "files"},
+		},
+		{
+			name: "default desc",
+			spec: "out-dir",
+			want: GenCmdArg{Name: "out-dir", Desc: "the out-dir", Var: "outDir"},
+		},
+		{name: "invalid name", spec: "-name", err: `invalid argument name in the spec "-name"`},
+		{name: "invalid flag", spec: "name:optional", err: `invalid argument flag "optional" in the spec "name:optional"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &GenCmdData{}
+			err := d.ParseArgs([]string{tt.spec})
+			if tt.err != "" {
+				assert.ErrMsg(t, err, tt.err)
+				return
+			}
+
+			assert.NoErr(t, err)
+			assert.Len(t, d.Args, 1)
+			assert.Eq(t, tt.want, *d.Args[0])
+		})
+	}
+
+	// only the required args are given on test
+	d := &GenCmdData{}
+	assert.NoErr(t, d.ParseArgs([]string{"name:required", "files:arrayed"}))
+	assert.Eq(t, []string{"name"}, d.TestArgs)
+}
+
+func TestNewGenCmdData(t *testing.T) {
+	d, err := NewGenCmdData("user:create", "", "cmd")
+	assert.NoErr(t, err)
+	assert.Eq(t, "user create", d.Path)
+	assert.Eq(t, "create", d.Name)
+	assert.Eq(t, "the user create command", d.Desc)
+	assert.Eq(t, "UserCreateCmd", d.VarName)
+	assert.Eq(t, "runUserCreate", d.FuncName)
+	assert.Eq(t, "userCreateOpts", d.OptsType)
+
+	_, err = NewGenCmdData(" ", "", "cmd")
+	assert.ErrMsg(t, err, "the command path cannot be empty")
+	_, err = NewGenCmdData("user:1st", "", "cmd")
+	assert.ErrMsg(t, err, `invalid command name "1st" in the path "user:1st"`)
+}
+
+func TestAddGoImport(t *testing.T) {
+	const path = "example.com/app/cmd"
+	tests := []struct {
+		name, src, want string
+	}{
+		{
+			name: "import block",
+			src:  "package main\n\nimport (\n\t\"fmt\"\n)\n",
+			want: "package main\n\nimport (\n\t\"fmt\"\n\t\"example.com/app/cmd\"\n)\n",
+		},
+		{
+			name: "single import",
+			src:  "package main\n\nimport \"fmt\"\n",
+			want: "package main\n\nimport (\n\t\"fmt\"\n\t\"example.com/app/cmd\"\n)\n",
+		},
+		{
+			name: "no imports",
+			src:  "package main\n\nfunc main() {}\n",
+			want: "package main\n\nimport \"example.com/app/cmd\"\n\nfunc main() {}\n",
+		},
+		{
+			name: "exists",
+			src:  "package main\n\nimport \"example.com/app/cmd\"\n",
+			want: "package main\n\nimport \"example.com/app/cmd\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Eq(t, tt.want, addGoImport(tt.src, path))
+		})
+	}
+}
+
+func TestGoImportPath(t *testing.T) {
+	root := t.TempDir()
+	assert.NoErr(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n\ngo 1.22\n"), 0644))
+
+	path, err := goImportPath(root)
+	assert.NoErr(t, err)
+	assert.Eq(t, "example.com/app", path)
+
+	// the dir not exists is allowed, it will be created on generate
+	path, err = goImportPath(filepath.Join(root, "internal", "cmd"))
+	assert.NoErr(t, err)
+	assert.Eq(t, "example.com/app/internal/cmd", path)
+
+	// invalid go.mod
+	bad := t.TempDir()
+	assert.NoErr(t, os.WriteFile(filepath.Join(bad, "go.mod"), []byte("go 1.22\n"), 0644))
+	_, err = goImportPath(bad)
+	assert.ErrSubMsg(t, err, "not found the module path in")
+}
+
+const testMainFile = `package main
+
+import "github.com/gookit/gcli/v3"
+
+func main() {
+	app := gcli.NewApp()
+	app.Run(nil)
+}
+`
+
+func TestAddCmdToMain(t *testing.T) {
+	root := t.TempDir()
+	mainFile := filepath.Join(root, "main.go")
+	assert.NoErr(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0644))
+
+	t.Run("other package", func(t *testing.T) {
+		assert.NoErr(t, os.WriteFile(mainFile, []byte(testMainFile), 0644))
+		d, err := NewGenCmdData("serve", "", "cmd")
+		assert.NoErr(t, err)
+
+		assert.NoErr(t, AddCmdToMain(mainFile, filepath.Join(root, "cmd"), d))
+		bs, err := os.ReadFile(mainFile)
+		assert.NoErr(t, err)
+		assert.Eq(t, `package main
+
+import (
+	"example.com/app/cmd"
+	"github.com/gookit/gcli/v3"
+)
+
+func main() {
+	app := gcli.NewApp()
+	app.Add(cmd.ServeCmd)
+	app.Run(nil)
+}
+`, string(bs))
+
+		// add again: not changed
+		assert.NoErr(t, AddCmdToMain(mainFile, filepath.Join(root, "cmd"), d))
+		bs2, err := os.ReadFile(mainFile)
+		assert.NoErr(t, err)
+		assert.Eq(t, string(bs), string(bs2))
+	})
+
+	t.Run("main package", func(t *testing.T) {
+		assert.NoErr(t, os.WriteFile(mainFile, []byte(testMainFile), 0644))
+		d, err := NewGenCmdData("serve", "", "main")
+		assert.NoErr(t, err)
+
+		assert.NoErr(t, AddCmdToMain(mainFile, root, d))
+		bs, err := os.ReadFile(mainFile)
+		assert.NoErr(t, err)
+		assert.StrContains(t, string(bs), "\tapp.Add(ServeCmd)\n\tapp.Run(nil)\n")
+		assert.StrContains(t, string(bs), "import \"github.com/gookit/gcli/v3\"\n")
+	})
+
+	t.Run("no Run call", func(t *testing.T) {
+		assert.NoErr(t, os.WriteFile(mainFile, []byte("package main\n\nfunc main() {}\n"), 0644))
+		d, err := NewGenCmdData("serve", "", "main")
+		assert.NoErr(t, err)
+		assert.ErrSubMsg(t, AddCmdToMain(mainFile, root, d), "not found the app Run() call")
+	})
+}
+
+// render the code and test file, then run the generated test by go.
+func TestGenCmdData_Render_compile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skip the compile test on -short")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("not found the go command")
+	}
+
+	d, err := NewGenCmdData("user:create", `create a "user"`, "gencmd")
+	assert.NoErr(t, err)
+	assert.NoErr(t, d.ParseOpts([]string{
+		"name:string:n:the user name; eg: \"inhere\"",
+		`dir:string::the dir. eg: C:\tmp`,
+		"timeout:duration",
+		"tags:strings",
+	}))
+	assert.NoErr(t, d.ParseArgs([]string{"group:required", "files:arrayed:the files"}))
+
+	// in the module, so the generated code can import gcli. testdata is ignored by ./...
+	assert.NoErr(t, os.MkdirAll("testdata", 0755))
+	dir, err := os.MkdirTemp("testdata", "gencmd")
+	assert.NoErr(t, err)
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+		_ = os.Remove("testdata") // only removed on it is empty
+	})
+
+	for tplName, file := range map[string]string{"cmd-code.tpl": "user_create.go", "cmd-test.tpl": "user_create_test.go"} {
+		src, err := d.Render(tplName)
+		assert.NoErr(t, err)
+		assert.NoErr(t, os.WriteFile(filepath.Join(dir, file), src, 0644))
+	}
+
+	out, err := exec.Command(goBin, "test", "-count=1", "./"+filepath.ToSlash(dir)).CombinedOutput()
+	assert.NoErr(t, err, string(out))
+}
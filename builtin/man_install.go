@@ -0,0 +1,148 @@
+package builtin
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/docgen"
+)
+
+// man install options. created for each command, the bound string value is used as the option default.
+type manInstallOpts struct {
+	prefix string
+	dir    string
+	noGzip bool
+	print  bool
+	check  bool
+}
+
+// ManInstall create a command to generate and install the man pages of the application.
+//
+// Pages are named by the app name, eg: `myapp.1` links all commands, `myapp-remote-add.1`
+// for the command `remote add`. Every page is checked by docgen.LintMan before install.
+//
+// Usage:
+//
+//	app.Add(builtin.ManInstall())
+//	// ./myapp man-install                  # install into /usr/local/share/man/man1
+//	// ./myapp man-install -p ~/.local      # install into ~/.local/share/man/man1
+//	// ./myapp man-install --print-path     # only print the target path and pages
+//	// ./myapp man-install --check          # only check the generated pages
+func ManInstall(fns ...func(c *gcli.Command)) *gcli.Command {
+	manOpts := &manInstallOpts{}
+	c := &gcli.Command{
+		Name:    "man-install",
+		Aliases: []string{"install-man"},
+		Desc:    "generate, check and install the man pages for current application",
+		Func: func(c *gcli.Command, _ []string) error {
+			return manOpts.doInstall(c)
+		},
+	}
+
+	prefix := os.Getenv("PREFIX")
+	if prefix == "" {
+		prefix = "/usr/local"
+	}
+
+	c.StrOpt(
+		&manOpts.prefix, "prefix", "p", prefix,
+		"the install prefix, pages will be installed into {prefix}/share/man/man1",
+	)
+	c.StrOpt(
+		&manOpts.dir, "dir", "d", "",
+		"install pages into the dir directly, will ignore the --prefix",
+	)
+	c.BoolOpt(&manOpts.noGzip, "no-gzip", "", false, "don't gzip the installed pages")
+	c.BoolOpt(&manOpts.print, "print-path", "", false, "only print the target dir and page files, don't install")
+	c.BoolOpt(&manOpts.check, "check", "c", false, "only check the generated pages, don't install")
+
+	for _, fn := range fns {
+		fn(c)
+	}
+	return c
+}
+
+func (o *manInstallOpts) doInstall(c *gcli.Command) error {
+	dir := o.dir
+	if dir == "" {
+		dir = filepath.Join(o.prefix, "share", "man", "man1")
+	}
+
+	opts := &docgen.Options{FileName: docgen.PrefixedFileName}
+	pages := docgen.RenderPages(c.App(), docgen.NewManRenderer(opts), opts)
+
+	// check pages before install, broken pages should not be shipped
+	var failed int
+	for _, page := range pages {
+		if err := docgen.LintMan(page.Content); err != nil {
+			failed++
+			color.Fprintf(c.ErrOut(), "<error>%s:</>\n", page.File)
+			_, _ = fmt.Fprintln(c.ErrOut(), err.Error())
+		}
+	}
+	if failed > 0 {
+		return c.NewErrf("%d of %d man pages have errors", failed, len(pages))
+	}
+	if o.check {
+		color.Fprintf(c.Out(), "<success>OK, all %d man pages are valid</>\n", len(pages))
+		return nil
+	}
+
+	if o.print {
+		_, _ = fmt.Fprintln(c.Out(), dir)
+		for _, page := range pages {
+			_, _ = fmt.Fprintln(c.Out(), filepath.Join(dir, o.manFileName(page.File)))
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, page := range pages {
+		content := []byte(page.Content)
+		if !o.noGzip {
+			var err error
+			if content, err = gzipBytes(content); err != nil {
+				return err
+			}
+		}
+
+		file := filepath.Join(dir, o.manFileName(page.File))
+		if err := os.WriteFile(file, content, 0644); err != nil {
+			return c.NewErrf("write man page error: %s", err.Error())
+		}
+		color.Fprintf(c.Out(), "<info>Installed:</> %s\n", file)
+	}
+
+	color.Fprintf(c.Out(), "\n<success>OK, %d man pages installed to: %s</>\n", len(pages), dir)
+	color.Fprintf(c.Out(), "<comment>TIP: run 'mandb' to update the man page index if needed.</>\n")
+	return nil
+}
+
+// manFileName the installed file name of the page
+func (o *manInstallOpts) manFileName(file string) string {
+	if o.noGzip {
+		return file
+	}
+	return file + ".gz"
+}
+
+// gzipBytes compress the contents. no name and mod time in header, keep output reproducible.
+func gzipBytes(bs []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(bs); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
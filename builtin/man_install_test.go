@@ -0,0 +1,88 @@
+package builtin_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/builtin"
+	"github.com/gookit/gcli/v3/gclitest"
+	"github.com/gookit/goutil/x/assert"
+)
+
+func newManApp() *gcli.App {
+	app := gcli.NewApp(func(a *gcli.App) {
+		a.Name = "demo"
+		a.Desc = "the demo app"
+	})
+	app.Add(&gcli.Command{
+		Name: "serve",
+		Desc: "start the server",
+		Func: func(c *gcli.Command, _ []string) error { return nil },
+	})
+	app.Add(builtin.ManInstall())
+	return app
+}
+
+func TestManInstall_check(t *testing.T) {
+	res := gclitest.Run(newManApp(), "man-install --check")
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "OK, all 3 man pages are valid\n", res.Stdout)
+	assert.Empty(t, res.Stderr)
+}
+
+func TestManInstall_printPath(t *testing.T) {
+	dir := t.TempDir()
+	res := gclitest.Run(newManApp(), "man-install --print-path -d "+dir)
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, dir+"\n"+
+		filepath.Join(dir, "demo.1.gz")+"\n"+
+		filepath.Join(dir, "demo-man-install.1.gz")+"\n"+
+		filepath.Join(dir, "demo-serve.1.gz")+"\n", res.Stdout)
+
+	// nothing installed
+	entries, err := os.ReadDir(dir)
+	assert.NoErr(t, err)
+	assert.Empty(t, entries)
+
+	// by the prefix
+	res = gclitest.Run(newManApp(), "man-install --print-path --no-gzip -p /opt/demo")
+	assert.NoErr(t, res.Err)
+	assert.StrContains(t, res.Stdout, filepath.Join("/opt/demo", "share", "man", "man1", "demo.1")+"\n")
+}
+
+func TestManInstall_install(t *testing.T) {
+	t.Run("gzip", func(t *testing.T) {
+		dir := t.TempDir()
+		res := gclitest.Run(newManApp(), "man-install -d "+dir)
+		assert.NoErr(t, res.Err)
+		assert.StrContains(t, res.Stdout, "Installed: "+filepath.Join(dir, "demo-serve.1.gz")+"\n")
+		assert.StrContains(t, res.Stdout, "OK, 3 man pages installed to: "+dir+"\n")
+
+		bs, err := os.ReadFile(filepath.Join(dir, "demo-serve.1.gz"))
+		assert.NoErr(t, err)
+		zr, err := gzip.NewReader(bytes.NewReader(bs))
+		assert.NoErr(t, err)
+		page, err := io.ReadAll(zr)
+		assert.NoErr(t, err)
+		assert.StrContains(t, string(page), ".TH ")
+	})
+
+	t.Run("no gzip", func(t *testing.T) {
+		dir := t.TempDir()
+		res := gclitest.Run(newManApp(), "man-install --no-gzip -d "+dir)
+		assert.NoErr(t, res.Err)
+		assert.StrContains(t, res.Stdout, "Installed: "+filepath.Join(dir, "demo.1")+"\n")
+
+		page, err := os.ReadFile(filepath.Join(dir, "demo-serve.1"))
+		assert.NoErr(t, err)
+		assert.StrContains(t, string(page), ".TH ")
+		assert.StrContains(t, string(page), "Start the server")
+		_, err = os.Stat(filepath.Join(dir, "demo-serve.1.gz"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,48 @@
+// Generated by the gcli gen-cmd command, edit it as needed.
+
+package {{.Package}}
+
+import (
+{{- range .StdImports}}
+	"{{.}}"
+{{- end}}
+{{if .StdImports}}
+{{end}}
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{if .Opts}}
+// {{.OptsType}} the options for the '{{.Path}}' command
+type {{.OptsType}} struct {
+{{- range .Opts}}
+	{{.Field}} {{.GoType}} `flag:"{{.Tag}}"`
+{{- end}}
+}
+
+// {{.OptsVar}} the option values of the '{{.Path}}' command
+var {{.OptsVar}} = &{{.OptsType}}{}
+{{end}}
+// {{.VarName}} {{.Desc}}
+var {{.VarName}} = &gcli.Command{
+	Name: "{{.Name}}",
+	Desc: {{printf "%q" .Desc}},
+	Config: func(c *gcli.Command) {
+{{- if .Opts}}
+		c.MustFromStruct({{.OptsVar}})
+{{- end}}
+{{- range .Args}}
+		c.AddArg("{{.Name}}", {{printf "%q" .Desc}}{{if or .Required .Arrayed}}, {{.Required}}{{end}}{{if .Arrayed}}, true{{end}})
+{{- end}}
+	},
+	Func: {{.FuncName}},
+}
+
+// {{.FuncName}} run the '{{.Path}}' command
+func {{.FuncName}}(c *gcli.Command, args []string) error {
+{{- range .Args}}
+	// {{.Var}} := c.Arg("{{.Name}}").{{if .Arrayed}}Strings(){{else}}String(){{end}}
+{{- end}}
+	// TODO: implement the command
+	return nil
+}
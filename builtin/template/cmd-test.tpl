@@ -0,0 +1,21 @@
+// Generated by the gcli gen-cmd command, edit it as needed.
+
+package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/gookit/gcli/v3"
+)
+
+func Test{{.VarName}}(t *testing.T) {
+	app := gcli.NewApp(func(a *gcli.App) {
+		a.ExitOnEnd = false
+	})
+	app.Add({{.VarName}})
+
+	err := app.Exec("{{.Name}}", []string{ {{- range $i, $v := .TestArgs}}{{if $i}}, {{end}}{{printf "%q" $v}}{{end}} })
+	if err != nil {
+		t.Fatal(err)
+	}
+}
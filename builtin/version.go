@@ -0,0 +1,57 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/gookit/gcli/v3"
+)
+
+// version command options
+var verOpts = &struct {
+	json  bool
+	short bool
+	deps  bool
+}{}
+
+// VersionCommand create the version command. display the app version and build metadata.
+//
+// Usage:
+//
+//	app.Add(builtin.VersionCommand())
+//	// run: app version [--json|--short|--deps]
+func VersionCommand(fns ...func(c *gcli.Command)) *gcli.Command {
+	c := &gcli.Command{
+		Name: "version",
+		Desc: "display the application version and build information",
+		Func: showVersion,
+		Examples: `{$binName} {$cmd}
+  {$binName} {$cmd} --json`,
+	}
+
+	c.BoolOpt(&verOpts.json, "json", "", false, "output the version and build information as JSON")
+	c.BoolOpt(&verOpts.short, "short", "s", false, "only output the short version line")
+	c.BoolOpt(&verOpts.deps, "deps", "", false, "also list the dependency modules on text output")
+
+	for _, fn := range fns {
+		fn(c)
+	}
+	return c
+}
+
+func showVersion(c *gcli.Command, _ []string) error {
+	if c.App() == nil {
+		return c.NewErr("the version command must be added to an app")
+	}
+
+	// TIP: the JSON output always contains the dependency modules
+	bi := c.App().BuildInfo()
+	switch {
+	case verOpts.json:
+		_, _ = fmt.Fprintln(c.Out(), bi.JSON())
+	case verOpts.short:
+		_, _ = fmt.Fprintln(c.Out(), bi.Short())
+	default:
+		_, _ = fmt.Fprint(c.Out(), bi.Format(verOpts.deps))
+	}
+	return nil
+}
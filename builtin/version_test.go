@@ -0,0 +1,61 @@
+package builtin_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/builtin"
+	"github.com/gookit/gcli/v3/gclitest"
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestVersionCommand(t *testing.T) {
+	oldCommit, oldTime := gcli.BuildCommit, gcli.BuildTime
+	defer func() { gcli.BuildCommit, gcli.BuildTime = oldCommit, oldTime }()
+	gcli.BuildCommit, gcli.BuildTime = "abc1234567890", "2024-05-01T10:00:00Z"
+
+	newApp := func() *gcli.App {
+		app := gcli.NewApp(func(a *gcli.App) {
+			a.Name = "demo"
+			a.Version = "1.2.0"
+		})
+		app.Add(builtin.VersionCommand())
+		return app
+	}
+
+	t.Run("text", func(t *testing.T) {
+		res := gclitest.Run(newApp(), "version")
+		assert.NoErr(t, res.Err)
+		assert.StrContains(t, res.Stdout, "demo version 1.2.0\n")
+		assert.StrContains(t, res.Stdout, "  Commit:      abc1234567890\n")
+		assert.StrContains(t, res.Stdout, "  Build time:  2024-05-01T10:00:00Z\n")
+		assert.NotContains(t, res.Stdout, "Deps:")
+	})
+
+	t.Run("short", func(t *testing.T) {
+		res := gclitest.Run(newApp(), "version --short")
+		assert.NoErr(t, res.Err)
+		assert.Eq(t, "1.2.0 (abc1234, 2024-05-01T10:00:00Z)\n", res.Stdout)
+	})
+
+	t.Run("deps", func(t *testing.T) {
+		res := gclitest.Run(newApp(), "version --deps")
+		assert.NoErr(t, res.Err)
+		assert.StrContains(t, res.Stdout, "demo version 1.2.0\n")
+		assert.StrContains(t, res.Stdout, "  Deps:\n")
+		assert.StrContains(t, res.Stdout, "    github.com/gookit/goutil ")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		res := gclitest.Run(newApp(), "version --json")
+		assert.NoErr(t, res.Err)
+
+		bi := &gcli.BuildInfo{}
+		assert.NoErr(t, json.Unmarshal([]byte(res.Stdout), bi))
+		assert.Eq(t, "demo", bi.Name)
+		assert.Eq(t, "1.2.0", bi.Version)
+		assert.Eq(t, "abc1234567890", bi.Commit)
+		assert.NotEmpty(t, bi.Deps)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3/gevent"
 	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/gcli/v3/internal/helper"
 	"github.com/gookit/goutil/arrutil"
 	"github.com/gookit/goutil/structs"
@@ -51,6 +52,31 @@ func (c HandlersChain) Last() RunnerFunc {
 	return nil
 }
 
+// MiddleFunc around(onion style) middleware definition.
+//
+// Call next() to run the rest middlewares and the command func, and it returns the final error.
+// So a middleware can do timing, tracing, recover, retry or inspect the error. eg:
+//
+//	func(c *gcli.Command, args []string, next func() error) error {
+//		start := time.Now()
+//		err := next()
+//		log.Printf("command %s run %s, err: %v", c.Path(), time.Since(start), err)
+//		return err
+//	}
+//
+// TIP: not call next() will abort the run, the command func will not be executed.
+type MiddleFunc func(c *Command, args []string, next func() error) error
+
+// gateMiddle convert a gate style handler(see Command.Use) to MiddleFunc, abort on it returns error.
+func gateMiddle(fn RunnerFunc) MiddleFunc {
+	return func(c *Command, args []string, next func() error) error {
+		if err := fn(c, args); err != nil {
+			return err
+		}
+		return next()
+	}
+}
+
 // Command a CLI command structure
 type Command struct {
 	// internal use
@@ -75,14 +101,17 @@ type Command struct {
 	Config func(c *Command)
 	// Hidden the command on render help
 	Hidden bool
+	// Deprecated mark the command is deprecated, the value is the message. eg: "use 'new-cmd' instead"
+	//
+	// A deprecated command still works, but it is hidden on help and completion,
+	// and a warning will be printed when it is used.
+	Deprecated string
 
 	// --- for middleware ---
-	// run error
-	runErr error
-	// middleware index number
-	middleIdx int8
-	// middleware functions
-	middles HandlersChain
+	// middleware functions, run in the registration order. see Use, Around
+	middles []MiddleFunc
+	// the rest of the chain for the running middleware. for the deprecated Next()
+	chainRest *chainRest
 	// errorHandler // loop find parent.errorHandler
 
 	// path names of the command. 'parent current'
@@ -120,6 +149,10 @@ type Command struct {
 	// Can use string-var in contents, eg:
 	//   {$cmd}, {$binName}, {$binDir}, {$workDir}, {$binWithCmd}, {$binWithPath}, {$fullCmd}
 	Help string
+	// I18n the translations of the Desc, Help and Examples, key is the locale. eg: "zh-CN"
+	//
+	// will be chosen by the current locale on render help. see gcli.SetLocale
+	I18n map[string]CmdText
 	// HelpRender custom render cmd help message
 	HelpRender func(c *Command)
 
@@ -141,6 +174,9 @@ type Command struct {
 	// localOptNames snapshots the command's own local option names before shared merge.
 	// 合并共享选项前的本地选项名快照, 用于区分局部定义与继承副本(保留局部 Required、跳过被局部覆盖的共享必填校验)。
 	localOptNames map[string]bool
+
+	// dryRun, assumeYes the values of --dry-run and --yes. see EnableDryRun, EnableYes
+	dryRun, assumeYes bool
 }
 
 // NewCommand create a new command instance.
@@ -185,9 +221,27 @@ func (c *Command) WithHidden() *Command {
 	return c
 }
 
+// WithDeprecated mark the command is deprecated, with a message. see Command.Deprecated
+func (c *Command) WithDeprecated(msg string) *Command {
+	c.Deprecated = msg
+	return c
+}
+
 // Use 注册一个或多个中间件, 按注册顺序在命令主函数前依次执行; 返回 c 以便链式调用。
+//
+// 这是前置检查(gate)式中间件: 返回 error 即中止, 后续中间件与主函数都不再执行。
+// 需要包裹主函数执行(计时/重试/查看最终 error)时, 请使用 Around。
 func (c *Command) Use(handlers ...RunnerFunc) *Command {
-	c.middles = append(c.middles, handlers...)
+	for _, fn := range handlers {
+		c.middles = append(c.middles, gateMiddle(fn))
+	}
+	return c
+}
+
+// Around 注册一个或多个环绕(洋葱模型)中间件, 调用 next() 执行后续中间件与主函数并得到最终 error。
+// 与 Use 注册的中间件共用同一条链, 按注册顺序执行; 返回 c 以便链式调用。
+func (c *Command) Around(mws ...MiddleFunc) *Command {
+	c.middles = append(c.middles, mws...)
 	return c
 }
 
@@ -205,6 +259,18 @@ func (c *Command) appOpts() *AppOptions {
 	return c.runOpts
 }
 
+// ownerOpts returns the options of the app, or the root command in standalone mode.
+// the config(verbose/strict/enhanceShort) and --no-pager are resolved through it.
+func (c *Command) ownerOpts() *AppOptions {
+	if c.app != nil {
+		return c.app.opts
+	}
+	if c.parent != nil {
+		return c.parent.ownerOpts()
+	}
+	return c.appOpts()
+}
+
 // SharedOpts 返回命令专属的共享选项持有器(惰性创建), 对标 cobra 的 PersistentFlags()。
 //
 // 在它上面像普通选项一样绑定(BoolOpt/StrOpt/Opt[T]/FromStruct/...), 这些选项会被本命令
@@ -218,11 +284,19 @@ func (c *Command) SharedOpts() *gflag.Flags {
 	return c.sharedFs
 }
 
+// HasSharedOpts reports whether the command defines shared options. see SharedOpts
+func (c *Command) HasSharedOpts() bool {
+	return c.sharedFs != nil && len(c.sharedFs.Opts()) > 0
+}
+
 // Disable set cmd is disabled
 func (c *Command) Disable() { c.disabled = true }
 
-// Visible return cmd is visible
-func (c *Command) Visible() bool { return c.Hidden == false }
+// Visible return cmd is visible. hidden or deprecated command is invisible.
+func (c *Command) Visible() bool { return !c.Hidden && c.Deprecated == "" }
+
+// IsDeprecated get cmd is deprecated
+func (c *Command) IsDeprecated() bool { return c.Deprecated != "" }
 
 // IsDisabled get cmd is disabled
 func (c *Command) IsDisabled() bool { return c.disabled }
@@ -293,7 +367,7 @@ func (c *Command) initialize() {
 
 	// check command name
 	cName := c.goodName()
-	Debugf("initialize the command '%s': init flags, run config func", cName)
+	c.Debugf("initialize the command '%s': init flags, run config func", cName)
 
 	c.initialized = true
 	c.pathNames = append(c.pathNames, cName)
@@ -332,15 +406,15 @@ func (c *Command) initialize() {
 
 // init base, ctx
 func (c *Command) initCommandBase(cName string) {
-	Logf(VerbCrazy, "init command c.base for the command: %s", cName)
+	c.Logf(VerbCrazy, "init command c.base for the command: %s", cName)
 
 	if c.Hooks == nil {
 		c.Hooks = &Hooks{}
 	}
 
 	if c.Ctx == nil {
-		Logf(VerbDebug, "cmd: %s - use the gCtx as command context", cName)
-		c.Ctx = gCtx
+		c.Logf(VerbDebug, "cmd: %s - create new context for the command", cName)
+		c.Ctx = NewCtx().InitCtx()
 	}
 
 	binWithPath := c.Ctx.binName + " " + c.Path()
@@ -364,21 +438,6 @@ func (c *Command) initCommandBase(cName string) {
 	c.base.cmdAliases = structs.NewAliases(aliasNameCheck)
 }
 
-// Next TODO processing, run all middleware handlers
-func (c *Command) Next() {
-	c.middleIdx++
-	s := int8(len(c.middles))
-
-	for ; c.middleIdx < s; c.middleIdx++ {
-		err := c.middles[c.middleIdx](c, c.RawArgs())
-		// will abort on error
-		if err != nil {
-			c.runErr = err
-			return
-		}
-	}
-}
-
 /*************************************************************
  * region standalone running
  *************************************************************/
@@ -425,16 +484,24 @@ func (c *Command) Run(args []string) (err error) {
 
 	// add default error handler.
 	if !c.HasHook(gevent.OnCmdRunError) {
-		c.On(gevent.OnCmdRunError, defaultErrHandler)
+		c.On(gevent.OnCmdRunError, defaultErrHandler, HookPriorityMin)
 	}
 
 	// binding global options
 	if !c.gOptBounded {
-		Debugf("cmd: %s - binding global options on standalone mode", c.Name)
+		c.Debugf("cmd: %s - binding global options on standalone mode", c.Name)
 		c.appOpts().bindingOpts(&c.Flags, gOpts)
 		c.gOptBounded = true
 	}
 
+	// expand '@file' argument files
+	if c.ArgFiles {
+		if args, err = expandArgFiles(c.ownerOpts(), args, 0); err != nil {
+			c.Fire(gevent.OnCmdRunError, map[string]any{gevent.KeyCmd: c.Name, gevent.KeyErr: err})
+			return err
+		}
+	}
+
 	// dispatch and parse flags and execute command
 	return c.innerDispatch(args)
 }
@@ -445,30 +512,34 @@ func (c *Command) Run(args []string) (err error) {
 
 // dispatch execute the command
 func (c *Command) innerDispatch(args []string) (err error) {
+	if c.Deprecated != "" {
+		warnOnce(c.ownerOpts(), "cmd:"+c.Path(), "command '%s' is deprecated, %s", c.Path(), c.Deprecated)
+	}
+
 	// parse command flags
 	args, err = c.parseOptions(args)
 	if err != nil {
 		if err == flag.ErrHelp {
-			Debugf("cmd: %s - parse opts return flag.ErrHelp, render command help", c.Name)
+			c.Debugf("cmd: %s - parse opts return flag.ErrHelp, render command help", c.Name)
 			return c.ShowHelp()
 		}
 
-		Debugf("cmd: %s - command options parse error", c.Name)
+		c.Debugf("cmd: %s - command options parse error", c.Name)
 		return err
 	}
 
 	// remaining args
 	if c.standalone {
 		if c.appOpts().ShowHelp {
-			Debugf("cmd: %s - ShowHelp is True, render command help", c.Name)
+			c.Debugf("cmd: %s - ShowHelp is True, render command help", c.Name)
 			return c.ShowHelp()
 		}
 
-		c.Fire(gevent.OnGlobalOptsParsed, map[string]any{"args": args})
+		c.Fire(gevent.OnGlobalOptsParsed, map[string]any{gevent.KeyArgs: args})
 	}
 
-	c.Fire(gevent.OnCmdOptParsed, map[string]any{"args": args})
-	Debugf("cmd: %s - remaining args on options parsed: %v", c.Name, args)
+	c.Fire(gevent.OnCmdOptParsed, map[string]any{gevent.KeyArgs: args})
+	c.Debugf("cmd: %s - remaining args on options parsed: %v", c.Name, args)
 
 	// find sub command
 	if len(args) > 0 {
@@ -487,23 +558,25 @@ func (c *Command) innerDispatch(args []string) (err error) {
 			// is not a sub command and has no arguments -> error
 			if !c.HasArguments() {
 				// fire events
-				hookData := map[string]any{"name": name, "args": args[1:]}
-				if c.Fire(gevent.OnCmdSubNotFound, hookData) {
-					return
-				}
-				if c.Fire(gevent.OnCmdNotFound, hookData) {
-					return
+				hookData := map[string]any{gevent.KeyName: name, gevent.KeyArgs: args[1:]}
+				// the listener can report the error by ctx.WithErr(). eg: run plugin command
+				for _, event := range []string{gevent.OnCmdSubNotFound, gevent.OnCmdNotFound} {
+					ctx := newHookCtx(event, c, hookData)
+					if c.fireCtx(event, ctx) {
+						return ctx.Err()
+					}
 				}
 
-				color.Error.Tips("%s - subcommand '%s' is not found", c.Name, name)
-				return newRunErr(ERR.ToInt(), c.NewErrf("%s - subcommand %q is not found", c.Name, name))
+				err := c.NewErr(gi18n.T("err.subcommandNotFound", c.Name, name))
+				c.ownerOpts().tips(color.Error, err.Error())
+				return newRunErr(ERR.ToInt(), err)
 			}
 		}
 	}
 
 	// not set command func and has sub commands.
 	if c.Func == nil && len(c.commands) > 0 {
-		Logf(VerbWarn, "cmd: %s - c.Func is empty, but has subcommands, render help", c.Name)
+		c.Logf(VerbWarn, "cmd: %s - c.Func is empty, but has subcommands, render help", c.Name)
 		return c.ShowHelp()
 	}
 
@@ -529,20 +602,31 @@ func (c *Command) innerExecute(args []string, igrErrHelp bool) (err error) {
 
 // do parse option flags, remaining is cmd args
 func (c *Command) parseOptions(args []string) (ss []string, err error) {
-	// apply global EnhanceShort to commands that don't set their own (command-level wins)
-	if c.ParserCfg().EnhanceShort == EnhanceShortNone && gOpts.enhanceShort > EnhanceShortNone {
-		c.ParserCfg().EnhanceShort = gOpts.enhanceShort
+	// apply the app(or process default) EnhanceShort to commands that don't set their own (command-level wins).
+	// only for the current parse, the command config is restored after parsed. so it won't stick on the
+	// command is reused by another app.
+	opts, cfg := c.ownerOpts(), c.ParserCfg()
+	if cmdLevel := cfg.EnhanceShort; cmdLevel == EnhanceShortNone {
+		level := opts.EnhanceShort()
+		if level == EnhanceShortNone && opts.StrictMode() {
+			// 短选项安全拆分交由 gflag EnhanceShort(仅全 bool 才拆，修复盲拆误伤取值短选项)
+			level = EnhanceShortMerge
+		}
+
+		if level > EnhanceShortNone {
+			cfg.EnhanceShort = level
+			defer func() { cfg.EnhanceShort = cmdLevel }()
+		}
 	}
 
 	// strict format options
-	if gOpts.strictMode && len(args) > 0 {
+	if opts.StrictMode() && len(args) > 0 {
 		args = strictFormatArgs(args) // 长选项形态规范化(--a/---name)
-		// 短选项安全拆分交由 gflag EnhanceShort(仅全 bool 才拆，修复盲拆误伤取值短选项)
-		if c.ParserCfg().EnhanceShort == EnhanceShortNone {
-			c.ParserCfg().EnhanceShort = EnhanceShortMerge
-		}
 	}
 
+	// the option Question prompts, the "-" value reads and the deprecated warnings use the app streams
+	c.Flags.SetIO(opts.in, opts.out, opts.errOut)
+
 	// args reorder(默认开启)在子命令边界处停止, 确保多级命令时只重排最终执行命令的 args。
 	c.Flags.SetReorderStop(func(name string) bool {
 		if c.isReorderStopName(name) {
@@ -551,15 +635,20 @@ func (c *Command) parseOptions(args []string) (ss []string, err error) {
 		return len(c.commands) > 0 && !c.HasArguments()
 	})
 
+	// warn on deprecated option or old option name is used.
+	c.Flags.OnDeprecated(func(name, msg string) {
+		warnDeprecatedOpt(opts, c.Path(), name, msg)
+	})
+
 	// 合并共享选项: 沿祖先链(含自身)从根到叶把共享选项并入 c.Flags, 使其在本命令段可解析。
 	// 幂等: sharedMerged 保证只合并一次; 合并后写在叶子段任意位置(配合 reorder)也能被识别。
 	c.mergeSharedOpts()
 
-	Debugf("cmd: %s - will parse options from args: %v", c.Name, args)
+	c.Debugf("cmd: %s - will parse options from args: %v", c.Name, args)
 
 	// parse options, don't contains command name.
 	if err = c.Parse(args); err != nil {
-		Logf(VerbCrazy, "cmd: %s - parse options, err: <red>%s</>", c.Name, err.Error())
+		c.Logf(VerbCrazy, "cmd: %s - parse options, err: <red>%s</>", c.Name, err.Error())
 		return
 	}
 
@@ -639,7 +728,7 @@ func (c *Command) validateSharedRequired() error {
 
 			// 类型感知判空(int 的空是 "0"、float 是 "0.0"、string 是 "")
 			if opt.IsEmpty() {
-				return fmt.Errorf("option '%s' is required", name)
+				return errors.New(gi18n.T("err.optRequired", name))
 			}
 		}
 	}
@@ -662,30 +751,30 @@ func (p panicErr) Error() string { return fmt.Sprint(p.val) }
 func (c *Command) doExecute(args []string) (err error) {
 	// 共享 Required 选项的延后校验: 到达实际执行命令时统一检查祖先链(含自身)的必填共享选项
 	if err = c.validateSharedRequired(); err != nil {
-		c.Fire(gevent.OnCmdRunError, map[string]any{"cmd": c.Name, "err": err})
-		Logf(VerbError, "command '%s' shared required option err: <red>%s</>", c.Name, err.Error())
+		c.Fire(gevent.OnCmdRunError, map[string]any{gevent.KeyCmd: c.Name, gevent.KeyErr: err})
+		c.Logf(VerbError, "command '%s' shared required option err: <red>%s</>", c.Name, err.Error())
 		return err
 	}
 
 	// collect and binding named argument
-	Debugf("cmd: %s - collect and binding named arguments", c.Name)
+	c.Debugf("cmd: %s - collect and binding named arguments", c.Name)
 	if err := c.ParseArgs(args); err != nil {
-		c.Fire(gevent.OnCmdRunError, map[string]any{"cmd": c.Name, "err": err})
-		Logf(VerbError, "binding command '%s' arguments err: <red>%s</>", c.Name, err.Error())
+		c.Fire(gevent.OnCmdRunError, map[string]any{gevent.KeyCmd: c.Name, gevent.KeyErr: err})
+		c.Logf(VerbError, "binding command '%s' arguments err: <red>%s</>", c.Name, err.Error())
 		return err
 	}
 
 	fnArgs := c.ExtraArgs()
-	c.Fire(gevent.OnCmdRunBefore, map[string]any{"args": fnArgs})
+	c.Fire(gevent.OnCmdRunBefore, map[string]any{gevent.KeyArgs: fnArgs})
 
 	// do call command handler func
 	if c.Func == nil {
-		Logf(VerbWarn, "the command '%s' no handler func to running", c.Name)
+		c.Logf(VerbWarn, "the command '%s' no handler func to running", c.Name)
 		c.Fire(gevent.OnCmdRunAfter, nil)
 		return
 	}
 
-	Debugf("cmd: %s - run command func with extra-args %v", c.Name, fnArgs)
+	c.Debugf("cmd: %s - run command func with extra-args %v", c.Name, fnArgs)
 
 	// recover panics from middleware/command func, convert to error.
 	// NOTE: recover 必须在 defer 内调用才有效; 仅在确实 panic 时触发 fireAfterExec,
@@ -707,28 +796,62 @@ func (c *Command) doExecute(args []string) (err error) {
 	return
 }
 
-// runWithMiddles 依次执行中间件(任一返回 error 即中止, 后续中间件与主函数都不再执行),
-// 全部通过后执行命令主函数。未注册中间件时等价于直接调用 c.Func。
+// runWithMiddles 按洋葱模型执行中间件链(app 级在前, 命令级在后), 链尾为命令主函数。
+// gate 式中间件返回 error 即中止; 环绕中间件不调用 next() 也会中止。未注册中间件时等价于直接调用 c.Func。
 func (c *Command) runWithMiddles(fnArgs []string) error {
 	// app 级中间件先于命令级执行(独立命令运行时 c.app 为 nil, 跳过)
+	var chain []MiddleFunc
 	if c.app != nil {
-		for _, mw := range c.app.middles {
-			if err := mw(c, fnArgs); err != nil {
-				return err
-			}
-		}
+		chain = append(chain, c.app.middles...)
 	}
-	for _, mw := range c.middles {
-		if err := mw(c, fnArgs); err != nil {
-			return err
+	chain = append(chain, c.middles...)
+
+	// 洋葱模型: 第 i 个中间件的 next() 执行第 i+1 个, 链尾为命令主函数
+	var call func(i int) error
+	call = func(i int) error {
+		if i == len(chain) {
+			return c.Func(c, fnArgs)
 		}
+
+		rest := &chainRest{run: func() error { return call(i + 1) }}
+		defer func(prev *chainRest) { c.chainRest = prev }(c.chainRest)
+		c.chainRest = rest
+		return chain[i](c, fnArgs, rest.next)
+	}
+	return call(0)
+}
+
+// chainRest the rest middlewares and the command func of the chain.
+type chainRest struct {
+	run func() error
+	// ran by Next(), the err will be returned by the next next() call.
+	ran bool
+	err error
+}
+
+// next run the rest of the chain. returns the result directly if it has been run by Next().
+func (r *chainRest) next() error {
+	if r.ran {
+		r.ran = false
+		return r.err
+	}
+	return r.run()
+}
+
+// Next run the rest middlewares and the command func of the chain, in a middleware.
+// The result is returned by the next() call of the middleware, for the gate middleware(see Use)
+// it is returned as the run error. Nothing to do on called outside the middleware.
+//
+// Deprecated: use Around and call next() instead, it returns the error of the rest chain.
+func (c *Command) Next() {
+	if rest := c.chainRest; rest != nil && !rest.ran {
+		rest.err, rest.ran = rest.run(), true
 	}
-	return c.Func(c, fnArgs)
 }
 
 func (c *Command) fireAfterExec(err error) {
 	if err != nil {
-		c.Fire(gevent.OnCmdRunError, map[string]any{"err": err})
+		c.Fire(gevent.OnCmdRunError, map[string]any{gevent.KeyCmd: c.Name, gevent.KeyErr: err})
 	} else {
 		c.Fire(gevent.OnCmdRunAfter, nil)
 	}
@@ -809,8 +932,12 @@ func (c *Command) goodName() string {
 
 // Fire event handler by name
 func (c *Command) Fire(event string, data map[string]any) (stop bool) {
-	hookCtx := newHookCtx(event, c, data)
-	Debugf("cmd: %s - trigger the event: <mga>%s</>", c.Name, event)
+	return c.fireCtx(event, newHookCtx(event, c, data))
+}
+
+// fireCtx fire event with the hook context. notify parent commands, app, then self.
+func (c *Command) fireCtx(event string, hookCtx *HookCtx) (stop bool) {
+	c.Debugf("cmd: %s - trigger the event: <mga>%s</>", c.Name, event)
 
 	// notify all parent commands
 	p := c.parent
@@ -829,14 +956,14 @@ func (c *Command) Fire(event string, data map[string]any) (stop bool) {
 	return c.Hooks.Fire(event, hookCtx)
 }
 
-// On add hook handler for a hook event
-func (c *Command) On(name string, handler HookFunc) {
-	Debugf("cmd: %s - register hook: <cyan>%s</>", c.Name, name)
+// On add hook handler for a hook event, returns the listener id. see Hooks.On
+func (c *Command) On(name string, handler HookFunc, priority ...int) int {
+	c.Debugf("cmd: %s - register hook: <cyan>%s</>", c.Name, name)
 
 	if c.Hooks == nil {
 		c.Hooks = &Hooks{}
 	}
-	c.Hooks.On(name, handler)
+	return c.Hooks.On(name, handler, priority...)
 }
 
 // Copy a new command for current
@@ -871,14 +998,33 @@ func (c *Command) NewErrf(format string, v ...any) error {
 	return fmt.Errorf(format, v...)
 }
 
+// LocaleText get the Desc, Help and Examples for the current locale.
+// will fall back to the default text on the translation is not set.
+func (c *Command) LocaleText() CmdText {
+	ct := CmdText{Desc: c.Desc, Help: c.Help, Examples: c.Examples}
+	if lt, ok := gi18n.Pick(c.I18n); ok {
+		if lt.Desc != "" {
+			ct.Desc = lt.Desc
+		}
+		if lt.Help != "" {
+			ct.Help = lt.Help
+		}
+		if lt.Examples != "" {
+			ct.Examples = lt.Examples
+		}
+	}
+	return ct
+}
+
 // HelpDesc format desc string for render help
 func (c *Command) HelpDesc() (desc string) {
-	if len(c.Desc) == 0 {
+	desc = c.LocaleText().Desc
+	if len(desc) == 0 {
 		return
 	}
 
 	// dump.P(desc)
-	desc = strutil.UpperFirst(c.Desc)
+	desc = strutil.UpperFirst(desc)
 	// contains help var "{$cmd}". replace on here is for 'app help'
 	if strings.Contains(desc, "{$") {
 		desc = strings.ReplaceAll(desc, "{$cmd}", color.WrapTag(c.Name, "mga"))
@@ -887,7 +1033,12 @@ func (c *Command) HelpDesc() (desc string) {
 	return wrapColor2string(desc)
 }
 
-// Logf print log message
-// func (c *Command) Logf(level uint, format string, v ...any) {
-// 	Logf(level, format, v...)
-// }
+// Logf print log message by the verbose level of the app(or root command in standalone mode).
+func (c *Command) Logf(level VerbLevel, format string, v ...any) {
+	logf(c.ownerOpts(), level, format, v...)
+}
+
+// Debugf print debug message by the verbose level of the app(or root command in standalone mode).
+func (c *Command) Debugf(format string, v ...any) {
+	logf(c.ownerOpts(), VerbDebug, format, v...)
+}
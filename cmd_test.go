@@ -736,6 +736,37 @@ func TestCommand_Use(t *testing.T) {
 		assert.Eq(t, []string{"mw1"}, order)
 	})
 
+	t.Run("deprecated Next", func(t *testing.T) {
+		defer gcli.ResetGOpts()
+
+		var order []string
+		c := gcli.NewCommand("mw-next", "test deprecated Next")
+		c.Use(
+			func(c *gcli.Command, args []string) error {
+				order = append(order, "mw1-before")
+				c.Next()
+				order = append(order, "mw1-after")
+				return nil
+			},
+			func(c *gcli.Command, args []string) error {
+				order = append(order, "mw2")
+				return nil
+			},
+		)
+		c.SetFunc(func(c *gcli.Command, args []string) error {
+			order = append(order, "func")
+			return errors.New("func error")
+		})
+
+		// the rest chain is run once, the error is returned
+		assert.ErrMsg(t, c.Run([]string{}), "func error")
+		assert.Eq(t, []string{"mw1-before", "mw2", "func", "mw1-after"}, order)
+
+		// nothing to do outside the middleware
+		c.Next()
+		assert.Len(t, order, 4)
+	})
+
 	t.Run("no middleware regression", func(t *testing.T) {
 		defer gcli.ResetGOpts()
 
@@ -751,3 +782,74 @@ func TestCommand_Use(t *testing.T) {
 		assert.True(t, called)
 	})
 }
+
+func TestCommand_Around(t *testing.T) {
+	t.Run("wrap and see final error", func(t *testing.T) {
+		defer gcli.ResetGOpts()
+
+		var order []string
+		var gotErr error
+		wantErr := errors.New("func error")
+
+		c := gcli.NewCommand("mw-around", "test around middleware")
+		ret := c.Around(func(c *gcli.Command, args []string, next func() error) error {
+			order = append(order, "around-before")
+			gotErr = next()
+			order = append(order, "around-after")
+			return gotErr
+		})
+		assert.Eq(t, c, ret)
+
+		// gate and around middlewares share one chain, by registration order
+		c.Use(func(c *gcli.Command, args []string) error {
+			order = append(order, "gate")
+			return nil
+		})
+		c.SetFunc(func(c *gcli.Command, args []string) error {
+			order = append(order, "func")
+			return wantErr
+		})
+
+		err := c.Run([]string{})
+		assert.ErrMsg(t, err, "func error")
+		assert.Eq(t, wantErr, gotErr)
+		assert.Eq(t, []string{"around-before", "gate", "func", "around-after"}, order)
+	})
+
+	t.Run("retry and abort", func(t *testing.T) {
+		defer gcli.ResetGOpts()
+
+		var runs int
+		c := gcli.NewCommand("mw-retry", "test retry middleware")
+		c.Around(func(c *gcli.Command, args []string, next func() error) (err error) {
+			for i := 0; i < 3; i++ {
+				if err = next(); err == nil {
+					return nil
+				}
+			}
+			return err
+		})
+		c.SetFunc(func(c *gcli.Command, args []string) error {
+			runs++
+			if runs < 2 {
+				return errors.New("temp error")
+			}
+			return nil
+		})
+
+		assert.NoErr(t, c.Run([]string{}))
+		assert.Eq(t, 2, runs)
+
+		// not call next() will abort
+		runs = 0
+		c2 := gcli.NewCommand("mw-abort2", "desc").WithFunc(func(c *gcli.Command, args []string) error {
+			runs++
+			return nil
+		})
+		c2.Around(func(c *gcli.Command, args []string, next func() error) error {
+			return errors.New("denied")
+		})
+		assert.ErrMsg(t, c2.Run([]string{}), "denied")
+		assert.Eq(t, 0, runs)
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3/internal/helper"
+	"github.com/gookit/goutil/arrutil"
 )
 
 // current supported shell for completion script generate
@@ -44,6 +45,10 @@ func (app *App) resolveCompletion(words []string) []string {
 	// 2. 拆分: cur 为当前正在输入的词, prev 为已完成的前序词
 	cur := words[len(words)-1]
 	prev := words[:len(words)-1]
+	// 展开用户别名(宏), 如 "st" -> "status --short"; 展开失败(如递归)时保持原样
+	if expanded, err := app.expandUserAlias(prev); err == nil {
+		prev = expanded
+	}
 
 	// 3. 用 prev 定位"当前命令上下文": 从 app 顶层开始, 逐个处理非选项词并尝试下钻。
 	//    - 选项词(以 - 开头)在定位时直接跳过;
@@ -77,16 +82,32 @@ func (app *App) resolveCompletion(words []string) []string {
 
 	// 3.5 选项值补全: 当前词不是选项, 且前一个词是"取值型"选项时, 补全该选项的候选值。
 	//     - 有 Choices: 给候选值; 无 Choices: 返回空(交给 shell 做文件名补全)。
-	//     - 前一个词是 bool 选项(不取值)时: 跳过, 落到下面的命令/子命令补全。
+	//     - 前一个词是 bool 选项或可选值选项(不消费下一个词)时: 跳过, 落到下面的命令/子命令补全。
 	if !strings.HasPrefix(cur, "-") && len(prev) > 0 {
 		last := prev[len(prev)-1]
-		if strings.HasPrefix(last, "-") {
-			if opt := optByRef(optsOfNode(curCmd, app), last); opt != nil && opt.TakesValue() {
+		if strings.HasPrefix(last, "-") && !strings.ContainsRune(last, '=') {
+			if opt := optByRef(optsOfNode(curCmd, app), last); opt != nil && opt.ConsumesNextArg() {
 				return filterAndSort(opt.Choices, cur)
 			}
 		}
 	}
 
+	// 3.6 `--opt=VAL` 写法的选项值补全(可选值选项只能这样赋值): 候选为 `--opt=choice`。
+	if strings.HasPrefix(cur, "-") {
+		if ref, _, ok := strings.Cut(cur, "="); ok {
+			opt := optByRef(optsOfNode(curCmd, app), ref)
+			if opt == nil || !opt.TakesValue() {
+				return nil
+			}
+
+			items := make([]string, 0, len(opt.Choices))
+			for _, choice := range opt.Choices {
+				items = append(items, ref+"="+choice)
+			}
+			return filterAndSort(items, cur)
+		}
+	}
+
 	// 4. 根据 cur 产出候选
 	var items []string
 	if strings.HasPrefix(cur, "-") {
@@ -106,28 +127,43 @@ func (app *App) resolveCompletion(words []string) []string {
 
 // topLevelNames 返回顶层补全名: 所有顶层命令名 + 命令别名 + 内置 help(去重、排序)。
 func (app *App) topLevelNames() []string {
-	var names []string
-	for name := range app.CmdNameMap() {
-		names = append(names, name)
-	}
-	// 顶层命令别名
-	for alias := range app.AliasesMapping() {
-		names = append(names, alias)
-	}
+	names := visibleCmdNames(&app.base)
 	// 内置 help 命令
 	names = append(names, HelpCommand)
+	// 用户别名(宏)
+	names = append(names, app.userAliasNames()...)
+	// 外部插件命令
+	for _, p := range app.pluginsOf("") {
+		names = append(names, p.Name)
+	}
 
 	return filterAndSort(names, "")
 }
 
 // completionSubNames 返回命令 c 的子命令名 + 子命令别名(去重、排序)。
 func completionSubNames(c *Command) []string {
+	names := visibleCmdNames(&c.base)
+	// 外部插件子命令
+	if c.app != nil {
+		for _, p := range c.app.pluginsOf(c.Path()) {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// visibleCmdNames 返回 b 下可见(非隐藏、未弃用)命令的名称 + 别名。
+func visibleCmdNames(b *base) []string {
 	var names []string
-	for name := range c.CmdNameMap() {
-		names = append(names, name)
+	for name, c := range b.commands {
+		if c.Visible() {
+			names = append(names, name)
+		}
 	}
-	for alias := range c.AliasesMapping() {
-		names = append(names, alias)
+	for alias, name := range b.AliasesMapping() {
+		if c, ok := b.commands[name]; !ok || c.Visible() {
+			names = append(names, alias)
+		}
 	}
 	return names
 }
@@ -139,11 +175,15 @@ func completionOptNames(node *Command, app *App) []string {
 
 	addOpts := func(opts map[string]*CliOpt, shortFn func(string) []string) {
 		for name, opt := range opts {
-			// 跳过隐藏选项(如框架内部的 --in-completion), 不应出现在补全候选中
-			if opt.Hidden {
+			// 跳过隐藏/弃用选项(如框架内部的 --in-completion), 不应出现在补全候选中
+			if !opt.Visible() {
 				continue
 			}
 			names = append(names, "--"+name)
+			// 长别名与主名同等补全
+			for _, alias := range opt.Aliases {
+				names = append(names, "--"+alias)
+			}
 			// 收集该选项的短名
 			for _, short := range shortFn(name) {
 				names = append(names, "-"+short)
@@ -178,12 +218,11 @@ func optByRef(opts map[string]*CliOpt, ref string) *CliOpt {
 	if opt, ok := opts[name]; ok {
 		return opt
 	}
-	// 否则按短名匹配
+	// 否则按短名、长别名、弃用旧名匹配
 	for _, opt := range opts {
-		for _, s := range opt.Shorts {
-			if s == name {
-				return opt
-			}
+		if arrutil.StringsHas(opt.Shorts, name) || arrutil.StringsHas(opt.Aliases, name) ||
+			arrutil.StringsHas(opt.DeprecatedNames, name) {
+			return opt
 		}
 	}
 	return nil
@@ -409,6 +448,12 @@ func buildForBashShell(app *App, data map[string]any) map[string]any {
 			}
 
 			opList = append(opList, pfx+opName)
+			// long alias names of the option
+			if opt := c.Opt(opName); opt != nil {
+				for _, alias := range opt.Aliases {
+					opList = append(opList, "--"+alias)
+				}
+			}
 		}
 
 		nameOpts[key] = strings.Join(opList, " ")
@@ -505,9 +550,23 @@ func buildForZshShell(app *App, data map[string]any) map[string]any {
 			opKey := pfx + opName
 			desTpl := "'%s[%s]'%s"
 
-			if shorts := c.ShortNames(opName); len(shorts) > 0 {
+			// multi names: shorts, name and long aliases. eg: {-d,--dry-run,--dryrun}
+			var aliases []string
+			if opt := c.Opt(opName); opt != nil {
+				aliases = opt.Aliases
+			}
+			if shorts := c.ShortNames(opName); len(shorts) > 0 || len(aliases) > 0 {
+				names := make([]string, 0, len(shorts)+len(aliases)+1)
+				for _, short := range shorts {
+					names = append(names, "-"+short)
+				}
+				names = append(names, pfx+opName)
+				for _, alias := range aliases {
+					names = append(names, "--"+alias)
+				}
+
 				desTpl = "%s'[%s]'%s"
-				opKey = fmt.Sprintf("{-%s,%s}", strings.Join(shorts, ",-"), pfx+opName)
+				opKey = "{" + strings.Join(names, ",") + "}"
 			}
 
 			// latest item
@@ -0,0 +1,109 @@
+package docgen
+
+import (
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3"
+)
+
+// escapeAdocCell 转义 AsciiDoc 表格单元格: `|` -> `\|`, 并去掉换行。
+func escapeAdocCell(s string) string {
+	return strings.ReplaceAll(cleanLine(s), "|", "\\|")
+}
+
+// adocListing 渲染 AsciiDoc listing 块(`----`), 保留多行原样。
+func adocListing(s string) string {
+	return "----\n" + s + "\n----\n\n"
+}
+
+// CmdAsciiDoc 渲染单个命令为 AsciiDoc 文档。
+func CmdAsciiDoc(c *gcli.Command) string {
+	var buf strings.Builder
+
+	buf.WriteString("= " + c.Path() + "\n\n")
+	if c.Desc != "" {
+		buf.WriteString(cleanLine(renderText(c, c.Desc)) + "\n\n")
+	}
+	if c.Deprecated != "" {
+		buf.WriteString("WARNING: Deprecated: " + cleanLine(c.Deprecated) + "\n\n")
+	}
+
+	if c.Help != "" {
+		buf.WriteString("== Synopsis\n\n")
+		buf.WriteString(renderText(c, c.Help) + "\n\n")
+	}
+
+	buf.WriteString(adocListing(c.Path() + " [--options ...] [arguments ...]"))
+
+	if len(c.Aliases) > 0 {
+		buf.WriteString("*Aliases*: `" + c.Aliases.String() + "`\n\n")
+	}
+
+	if rows := visibleOptRows(c); len(rows) > 0 {
+		buf.WriteString("== Options\n\n")
+		buf.WriteString("[cols=\"2,1,1,1,4\",options=\"header\"]\n|===\n")
+		buf.WriteString("|Option |Type |Default |Required |Description\n")
+		for _, r := range rows {
+			buf.WriteString("|`" + escapeAdocCell(r.Name) + "` |" + escapeAdocCell(r.Type) + " |" +
+				escapeAdocCell(r.Default) + " |" + r.Required + " |" + escapeAdocCell(r.Desc) + "\n")
+		}
+		buf.WriteString("|===\n\n")
+	}
+
+	if args := c.Args(); len(args) > 0 {
+		buf.WriteString("== Arguments\n\n")
+		buf.WriteString("[cols=\"2,1,4\",options=\"header\"]\n|===\n")
+		buf.WriteString("|Argument |Required |Description\n")
+		for _, arg := range args {
+			required := "No"
+			if arg.Required {
+				required = "Yes"
+			}
+			buf.WriteString("|`" + escapeAdocCell(argName(arg)) + "` |" + required + " |" +
+				escapeAdocCell(renderText(c, arg.Desc)) + "\n")
+		}
+		buf.WriteString("|===\n\n")
+	}
+
+	if c.Examples != "" {
+		buf.WriteString("== Examples\n\n")
+		buf.WriteString(adocListing(examplesText(c)))
+	}
+
+	// SubCommands -> xref 链接(文件名与 Tree 一致)
+	if subs := c.Commands(); len(subs) > 0 {
+		buf.WriteString("== SubCommands\n\n")
+		for _, sub := range sortedSubs(subs) {
+			buf.WriteString("* xref:" + cmdFileName(sub) + ".adoc[" + sub.Name + "] - " + cmdDesc(sub) + "\n")
+		}
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("NOTE: Auto generated by gcli docgen.\n")
+	return buf.String()
+}
+
+// AppAsciiDoc 渲染应用概览 AsciiDoc 文档: 标题/描述 + 顶层命令列表(含 xref 链接)。
+func AppAsciiDoc(app *gcli.App) string {
+	var buf strings.Builder
+
+	buf.WriteString("= " + app.Name + "\n\n")
+	if app.Desc != "" {
+		buf.WriteString(cleanLine(color.ClearTag(app.ReplacePairs(app.Desc))) + "\n\n")
+	}
+	if app.Version != "" {
+		buf.WriteString("*Version*: " + app.Version + "\n\n")
+	}
+
+	if cmds := app.Commands(); len(cmds) > 0 {
+		buf.WriteString("== Commands\n\n")
+		for _, c := range sortedSubs(cmds) {
+			buf.WriteString("* xref:" + cmdFileName(c) + ".adoc[" + c.Name + "] - " + cmdDesc(c) + "\n")
+		}
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("NOTE: Auto generated by gcli docgen.\n")
+	return buf.String()
+}
@@ -3,7 +3,9 @@ package docgen_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gookit/gcli/v3"
 	"github.com/gookit/gcli/v3/docgen"
@@ -151,3 +153,339 @@ func TestMarkdownTree(t *testing.T) {
 	_, err = os.Stat(filepath.Join(dir, "demo_child.md"))
 	assert.NoErr(t, err)
 }
+
+func TestDocgen_deprecated(t *testing.T) {
+	app := gcli.NewApp(func(a *gcli.App) { a.Name = "demoapp" })
+	app.Add(gcli.NewCommand("old", "the old command", func(c *gcli.Command) {
+		var name, dir string
+		c.StrOpt2(&name, "name", "the name", gflag.WithDeprecated("use '--user' instead"))
+		c.StrOpt2(&dir, "work-dir", "the work dir", gflag.WithDeprecatedNames("workdir"))
+	}).WithDeprecated("use 'new' instead"))
+
+	c := app.GetCommand("old")
+	md := docgen.CmdMarkdown(c)
+	assert.StrContains(t, md, "> **Deprecated**: use 'new' instead")
+	assert.StrContains(t, md, "(deprecated: use '--user' instead) the name")
+	assert.StrContains(t, md, "(deprecated names: --workdir)")
+	assert.StrContains(t, docgen.AppMarkdown(app), "old command (deprecated)")
+
+	man := docgen.CmdMan(c)
+	assert.StrContains(t, man, ".SH DEPRECATED")
+	assert.StrContains(t, man, "(deprecated names: \\-\\-workdir)")
+}
+
+func TestCmdRST(t *testing.T) {
+	app := newTestApp()
+	rst := docgen.CmdRST(app.GetCommand("demo"))
+
+	assert.StrContains(t, rst, "demo\n====\n")
+	assert.StrContains(t, rst, "Synopsis\n--------\n")
+	assert.StrContains(t, rst, ".. list-table::")
+	assert.StrContains(t, rst, "   * - ``-n, --name``")
+	assert.StrContains(t, rst, "``others...``")
+	assert.StrContains(t, rst, "Examples\n--------\n\n::\n\n    ")
+	assert.StrContains(t, rst, "demo --name tom arg0 arg1 # run the demo\n    ")
+	assert.StrContains(t, rst, ":doc:`child <demo_child>`")
+	assert.NotContains(t, rst, "<cyan>")
+
+	idx := docgen.AppRST(app)
+	assert.StrContains(t, idx, "demoapp\n=======\n")
+	assert.StrContains(t, idx, ".. toctree::")
+	assert.StrContains(t, idx, "   demo\n   demo_child\n")
+}
+
+func TestCmdAsciiDoc(t *testing.T) {
+	app := newTestApp()
+	doc := docgen.CmdAsciiDoc(app.GetCommand("demo"))
+
+	assert.StrContains(t, doc, "= demo\n")
+	assert.StrContains(t, doc, "== Options")
+	assert.StrContains(t, doc, "|`-n, --name` |string | |Yes |the name option")
+	assert.StrContains(t, doc, "== Examples\n\n----\n")
+	assert.StrContains(t, doc, "demo --name tom arg0 arg1 # run the demo\n")
+	assert.StrContains(t, doc, "xref:demo_child.adoc[child]")
+	assert.StrContains(t, docgen.AppAsciiDoc(app), "xref:demo.adoc[demo]")
+}
+
+func TestAppHTML(t *testing.T) {
+	app := newTestApp()
+	page := docgen.AppHTML(app)
+
+	assert.StrContains(t, page, "<title>demoapp</title>")
+	// 侧边栏命令树: 子命令嵌套在父命令下
+	assert.StrContains(t, page, `<li><a href="#cmd-demo">demo</a><ul>`)
+	assert.StrContains(t, page, `<li><a href="#cmd-demo-child">child</a></li>`)
+	assert.StrContains(t, page, `<section id="cmd-demo-child">`)
+	assert.StrContains(t, page, "<code>-n, --name</code>")
+	// 示例被转义且保留多行
+	assert.StrContains(t, page, "demo --name tom arg0 arg1 # run the demo\n")
+	assert.StrContains(t, page, "demo --name tom -v arg0 # verbose run</code></pre>")
+}
+
+func TestGenerate(t *testing.T) {
+	app := newTestApp()
+	assert.Eq(t, []string{"adoc", "html", "man", "md", "rst"}, docgen.Formats())
+
+	for format, files := range map[string][]string{
+		"rst":      {"index.rst", "demo.rst", "demo_child.rst"},
+		"asciidoc": {"index.adoc", "demo.adoc", "demo_child.adoc"},
+		"html":     {"index.html"},
+	} {
+		dir := t.TempDir()
+		assert.NoErr(t, docgen.Generate(app, format, dir))
+		for _, file := range files {
+			_, err := os.Stat(filepath.Join(dir, file))
+			assert.NoErr(t, err, format+": "+file)
+		}
+	}
+
+	// html 为单页, 不写命令文件
+	dir := t.TempDir()
+	assert.NoErr(t, docgen.Generate(app, "html", dir))
+	_, err := os.Stat(filepath.Join(dir, "demo.html"))
+	assert.Err(t, err)
+
+	err = docgen.Generate(app, "pdf", dir)
+	assert.ErrMsg(t, err, `invalid format "pdf", allow: adoc, html, man, md, rst`)
+}
+
+func TestRegister(t *testing.T) {
+	docgen.Register("txt", &docgen.FuncRenderer{
+		FileExt: ".txt",
+		CmdFn:   func(c *gcli.Command) string { return c.Path() + ": " + c.Desc },
+	}, "text")
+	t.Cleanup(func() {
+		docgen.Unregister("txt")
+		_, ok := docgen.Get("text")
+		assert.False(t, ok)
+	})
+
+	r, ok := docgen.Get("text")
+	assert.True(t, ok)
+	assert.Eq(t, ".txt", r.Ext())
+	assert.Eq(t, "", r.AppDoc(newTestApp()))
+
+	dir := t.TempDir()
+	assert.NoErr(t, docgen.Generate(newTestApp(), "txt", dir))
+	bs, err := os.ReadFile(filepath.Join(dir, "demo_child.txt"))
+	assert.NoErr(t, err)
+	assert.Eq(t, "demo child: This is a child command", string(bs))
+}
+
+func TestMarkdownTreeCustom(t *testing.T) {
+	app := newTestApp()
+	dir := t.TempDir()
+
+	var files []string
+	err := docgen.MarkdownTreeCustom(app, dir, &docgen.Options{
+		IndexName: "_index",
+		FileName:  docgen.NestedFileName,
+		FilePrepender: func(file string, c *gcli.Command) string {
+			files = append(files, file)
+			if c == nil {
+				return "---\ntitle: \"CLI reference\"\n---\n\n"
+			}
+			return "---\ntitle: \"" + c.Path() + "\"\nslug: " + c.Name + "\n---\n\n"
+		},
+		LinkHandler: func(c *gcli.Command, link string) string {
+			return "/cli/" + strings.TrimSuffix(link, ".md") + "/"
+		},
+		Footer: "> Generated on {date}.",
+		Date:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"_index.md", "demo.md", "demo/child.md"}, files)
+
+	bs, err := os.ReadFile(filepath.Join(dir, "_index.md"))
+	assert.NoErr(t, err)
+	assert.StrContains(t, string(bs), "---\ntitle: \"CLI reference\"\n---\n\n# demoapp")
+	assert.StrContains(t, string(bs), "[demo](/cli/demo/)")
+
+	bs, err = os.ReadFile(filepath.Join(dir, "demo.md"))
+	assert.NoErr(t, err)
+	md := string(bs)
+	assert.StrContains(t, md, "slug: demo\n")
+	// 链接相对当前文档: demo.md -> demo/child.md
+	assert.StrContains(t, md, "[child](/cli/demo/child/)")
+	assert.StrContains(t, md, "> Generated on 2024-05-01.")
+	assert.NotContains(t, md, "Auto generated by gcli docgen.")
+
+	_, err = os.Stat(filepath.Join(dir, "demo", "child.md"))
+	assert.NoErr(t, err)
+}
+
+func TestCmdMarkdownCustom(t *testing.T) {
+	app := newTestApp()
+	c := app.GetCommand("demo")
+
+	md := docgen.CmdMarkdownCustom(c, &docgen.Options{DisableFooter: true})
+	assert.StrContains(t, md, "[child](demo_child.md)")
+	assert.NotContains(t, md, "Auto generated by gcli docgen.")
+
+	// 嵌套文件名: 从 demo/child.md 指回其他文档需使用 ../
+	opts := &docgen.Options{FileName: docgen.NestedFileName}
+	md = docgen.CmdMarkdownCustom(c, opts)
+	assert.StrContains(t, md, "[child](demo/child.md)")
+	assert.StrContains(t, md, "Auto generated by gcli docgen.")
+	assert.StrContains(t, docgen.AppMarkdownCustom(app, opts), "[demo](demo.md)")
+}
+
+func TestSingleMarkdown(t *testing.T) {
+	app := newTestApp()
+	var env string
+	app.GetCommand("demo").SharedOpts().StrOpt(&env, "env", "e", "dev", "the shared env option")
+
+	md := docgen.SingleMarkdown(app, &docgen.Options{HeadingLevel: 2, DisableFooter: true})
+	assert.StrContains(t, md, "## demoapp\n")
+	// 目录: 嵌套列表 + 锚点
+	assert.StrContains(t, md, "### Commands\n\n- [demo](#demo) - This is a demo command for test\n  - [demo child](#demo-child) - This is a child command\n")
+	// 标题级别跟随命令层级
+	assert.StrContains(t, md, "\n### demo\n")
+	assert.StrContains(t, md, "\n#### demo child\n")
+	// Help/Examples 完全展开
+	assert.StrContains(t, md, "**Synopsis**:\n\nlong help message for demo command\n")
+	assert.StrContains(t, md, "demo --name tom -v arg0 # verbose run\n```")
+	assert.NotContains(t, md, "{$fullCmd}")
+	// 共享选项只在定义处列出一次, 子命令仅链接
+	assert.StrContains(t, md, "**Shared Options**:")
+	assert.Eq(t, 1, strings.Count(md, "`-e, --env`"))
+	assert.StrContains(t, md, "Also accepts the shared options of: [demo](#demo)")
+	assert.NotContains(t, md, "Auto generated by gcli docgen.")
+}
+
+func TestSingleCmdMarkdown(t *testing.T) {
+	var env, dir string
+	sub := gcli.NewCommand("child", "the child command", func(c *gcli.Command) {
+		c.StrOpt(&dir, "dir", "d", "", "the work dir")
+	})
+	sub.Examples = "{$fullCmd} -d ./src"
+
+	c := gcli.NewCommand("tool", "the tool command", func(c *gcli.Command) {
+		c.SharedOpts().StrOpt(&env, "env", "e", "dev", "the shared env option")
+	})
+	c.Subs = []*gcli.Command{sub}
+
+	// 命令未初始化: 渲染时强制初始化, 加载子命令并展开帮助变量
+	md := docgen.SingleCmdMarkdown(c, nil)
+	assert.StrContains(t, md, "# tool\n")
+	assert.StrContains(t, md, "- [tool child](#tool-child) - The child command\n")
+	assert.StrContains(t, md, "## tool child\n")
+	assert.StrContains(t, md, "tool child -d ./src\n")
+	assert.NotContains(t, md, "{$fullCmd}")
+	assert.Eq(t, 1, strings.Count(md, "`-e, --env`"))
+	assert.StrContains(t, md, "Auto generated by gcli docgen.")
+
+	// 从子命令开始: 祖先的共享选项在根处列出
+	md = docgen.SingleCmdMarkdown(sub, nil)
+	assert.StrContains(t, md, "# tool child\n")
+	assert.StrContains(t, md, "**Inherited Options**:")
+	assert.StrContains(t, md, "`-e, --env`")
+}
+
+func TestDocMeta(t *testing.T) {
+	app := newTestApp()
+	app.DocMeta = gcli.DocMeta{
+		ExitCodes: []gcli.DocItem{{Name: "0", Desc: "success"}, {Name: "1", Desc: "runtime error"}},
+		Files:     []gcli.DocItem{{Name: "~/.demoapp.yaml", Desc: "the user config file"}},
+		Authors:   []string{"inhere <in.798@qq.com>"},
+		BugURL:    "https://github.com/gookit/gcli/issues",
+		Date:      "May 2024",
+		Manual:    "Demo Manual",
+	}
+
+	var token string
+	app.Add(&gcli.Command{
+		Name: "login",
+		Desc: "login to the server",
+		Config: func(c *gcli.Command) {
+			c.StrOpt(&token, "token", "t", "${DEMO_TOKEN}", "the auth token")
+		},
+		Func: func(c *gcli.Command, _ []string) error { return nil },
+	})
+	c := app.GetCommand("login")
+	c.DocMeta.SeeAlso = []string{"ssh(1)"}
+	c.DocMeta.ExitCodes = []gcli.DocItem{{Name: "1", Desc: "login failed"}}
+
+	man := docgen.CmdMan(c)
+	assert.StrContains(t, man, `.TH "LOGIN" "1" "May 2024" "demoapp 0.1.0\-dev" "Demo Manual"`)
+	assert.StrContains(t, man, ".SH EXIT STATUS\n.TP\n\\fB0\\fR\nsuccess\n.TP\n\\fB1\\fR\nlogin failed\n")
+	assert.NotContains(t, man, "runtime error")
+	// 选项的 ENV 绑定自动加入 ENVIRONMENT
+	assert.StrContains(t, man, ".SH ENVIRONMENT\n.TP\n\\fBDEMO_TOKEN\\fR\ndefault value of \\-\\-token: the auth token\n")
+	assert.StrContains(t, man, ".SH FILES\n.TP\n\\fB~/.demoapp.yaml\\fR\n")
+	assert.StrContains(t, man, ".SH AUTHORS\ninhere <in.798@qq.com>\n")
+	assert.StrContains(t, man, ".SH REPORTING BUGS\nReport bugs to: https://github.com/gookit/gcli/issues\n")
+	// 应用页面与兄弟命令自动加入 SEE ALSO
+	assert.StrContains(t, man, ".SH SEE ALSO\n\\fBdemoapp\\fR(1), \\fBdemo\\fR(1), ssh(1)\n")
+
+	md := docgen.CmdMarkdown(c)
+	assert.StrContains(t, md, "## Environment\n\n- `DEMO_TOKEN` - default value of --token: the auth token\n")
+	assert.StrContains(t, md, "## Exit Status\n\n- `0` - success\n- `1` - login failed\n")
+	assert.StrContains(t, md, "## See Also\n\n- [demo](demo.md) - This is a demo command for test\n- ssh(1)\n")
+	assert.StrContains(t, md, "## Reporting Bugs\n\nReport bugs to: https://github.com/gookit/gcli/issues\n")
+
+	// 子命令: 父命令在 SEE ALSO 中, man 还包含子命令
+	assert.StrContains(t, docgen.CmdMarkdown(app.GetCommand("demo").Match([]string{"child"})), "## See Also\n\n- [demo](demo.md)")
+	assert.StrContains(t, docgen.CmdMan(app.GetCommand("demo")), "\\fBdemo_child\\fR(1)")
+
+	md = docgen.AppMarkdown(app)
+	assert.StrContains(t, md, "## Files\n\n- `~/.demoapp.yaml` - the user config file\n")
+	assert.StrContains(t, md, "## Authors\n\n- inhere <in.798@qq.com>\n")
+}
+
+func TestAppMan(t *testing.T) {
+	app := newTestApp()
+	man := docgen.AppMan(app)
+
+	assert.StrContains(t, man, `.TH "DEMOAPP" "1"`)
+	assert.StrContains(t, man, ".SH NAME\ndemoapp \\- the demo app for docgen test\n")
+	assert.StrContains(t, man, ".SH COMMANDS\n.TP\n\\fBdemo\\fR\nThis is a demo command for test (see \\fBdemo\\fR(1))\n")
+	assert.StrContains(t, man, ".SH SEE ALSO\n\\fBdemo\\fR(1), \\fBdemo_child\\fR(1)\n")
+	assert.NoErr(t, docgen.LintMan(man))
+
+	// ManTree 另写应用页面 {app}.1
+	dir := t.TempDir()
+	assert.NoErr(t, docgen.ManTree(app, dir))
+	_, err := os.Stat(filepath.Join(dir, "demoapp.1"))
+	assert.NoErr(t, err)
+}
+
+func TestManTreeCustom(t *testing.T) {
+	app := newTestApp()
+	dir := t.TempDir()
+
+	opts := &docgen.Options{FileName: docgen.PrefixedFileName}
+	assert.NoErr(t, docgen.ManTreeCustom(app, dir, opts))
+
+	bs, err := os.ReadFile(filepath.Join(dir, "demoapp-demo-child.1"))
+	assert.NoErr(t, err)
+	man := string(bs)
+	assert.StrContains(t, man, `.TH "DEMOAPP\-DEMO\-CHILD" "1"`)
+	assert.StrContains(t, man, ".SH NAME\ndemoapp\\-demo\\-child \\- This is a child command\n")
+	assert.StrContains(t, man, "\\fBdemoapp\\-demo\\fR(1)")
+
+	pages := docgen.RenderPages(app, docgen.NewManRenderer(opts), opts)
+	assert.Len(t, pages, 3)
+	assert.Eq(t, "demoapp.1", pages[0].File)
+	assert.Nil(t, pages[0].Cmd)
+	assert.Eq(t, "demoapp-demo.1", pages[1].File)
+	assert.StrContains(t, pages[0].Content, "\\fBdemoapp\\-demo\\-child\\fR(1)")
+	for _, page := range pages {
+		assert.NoErr(t, docgen.LintMan(page.Content), page.File)
+	}
+}
+
+func TestLintMan(t *testing.T) {
+	app := newTestApp()
+	assert.NoErr(t, docgen.LintMan(docgen.CmdMan(app.GetCommand("demo"))))
+
+	err := docgen.LintMan(".TH \"DEMO\" \"1\"\n.SH NAME\n.\\\" a comment\n.nf\n.nf\ntext\n.fi\n.fi\n.hello world\n")
+	assert.ErrMsg(t, err, "line 5: nested .nf, the .nf at line 4 is not closed\n"+
+		"line 8: unbalanced .fi without .nf\n"+
+		"line 9: unknown macro or unescaped leading \".\": .hello world")
+
+	err = docgen.LintMan(".SH NAME\n.nf\n'quoted\n")
+	assert.ErrMsg(t, err, "missing .TH header\n"+
+		"line 3: unknown macro or unescaped leading \"'\": 'quoted\n"+
+		"line 2: unclosed .nf, missing .fi")
+}
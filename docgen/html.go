@@ -0,0 +1,160 @@
+package docgen
+
+import (
+	"html"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3"
+)
+
+// htmlStyle 单页 HTML 文档的内联样式
+const htmlStyle = `body{margin:0;font-family:-apple-system,"Segoe UI",Helvetica,Arial,sans-serif;color:#24292f;line-height:1.5}
+nav{position:fixed;top:0;bottom:0;left:0;width:240px;overflow:auto;padding:16px;background:#f6f8fa;border-right:1px solid #d0d7de;box-sizing:border-box}
+nav ul{list-style:none;padding-left:14px;margin:0}nav>ul{padding-left:0}nav a{color:#0969da;text-decoration:none}
+main{margin-left:240px;padding:16px 32px;max-width:960px}section{border-bottom:1px solid #d0d7de;padding-bottom:16px}
+table{border-collapse:collapse}th,td{border:1px solid #d0d7de;padding:4px 10px;text-align:left}
+pre{background:#f6f8fa;padding:10px;overflow:auto}code{background:#f6f8fa;padding:0 3px}.deprecated{color:#cf222e}
+footer{color:#57606a;font-size:12px;padding:16px 0}`
+
+// cmdAnchor 命令在单页 HTML 中的锚点 id。eg: `remote add` -> `cmd-remote-add`
+func cmdAnchor(c *gcli.Command) string {
+	return "cmd-" + strings.ReplaceAll(c.Path(), " ", "-")
+}
+
+// htmlTable 渲染 HTML 表格, 单元格内容需已转义。
+func htmlTable(header []string, rows [][]string) string {
+	var sb strings.Builder
+	sb.WriteString("<table>\n<tr>")
+	for _, h := range header {
+		sb.WriteString("<th>" + h + "</th>")
+	}
+	sb.WriteString("</tr>\n")
+	for _, row := range rows {
+		sb.WriteString("<tr>")
+		for _, cell := range row {
+			sb.WriteString("<td>" + cell + "</td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</table>\n")
+	return sb.String()
+}
+
+// CmdHTML 渲染单个命令为 HTML 片段(`<section>`, 带锚点), 供 AppHTML 组装单页。
+func CmdHTML(c *gcli.Command) string {
+	var buf strings.Builder
+	esc := html.EscapeString
+
+	buf.WriteString(`<section id="` + cmdAnchor(c) + `">` + "\n")
+	buf.WriteString("<h2>" + esc(c.Path()) + "</h2>\n")
+	if c.Desc != "" {
+		buf.WriteString("<p>" + esc(cleanLine(renderText(c, c.Desc))) + "</p>\n")
+	}
+	if c.Deprecated != "" {
+		buf.WriteString(`<p class="deprecated"><strong>Deprecated</strong>: ` + esc(cleanLine(c.Deprecated)) + "</p>\n")
+	}
+	if c.Help != "" {
+		buf.WriteString("<h3>Synopsis</h3>\n<p>" + esc(renderText(c, c.Help)) + "</p>\n")
+	}
+
+	buf.WriteString("<pre><code>" + esc(c.Path()+" [--options ...] [arguments ...]") + "</code></pre>\n")
+	if len(c.Aliases) > 0 {
+		buf.WriteString("<p><strong>Aliases</strong>: <code>" + esc(c.Aliases.String()) + "</code></p>\n")
+	}
+
+	if rows := visibleOptRows(c); len(rows) > 0 {
+		cells := make([][]string, 0, len(rows))
+		for _, r := range rows {
+			cells = append(cells, []string{"<code>" + esc(r.Name) + "</code>", esc(r.Type), esc(r.Default), r.Required, esc(r.Desc)})
+		}
+		buf.WriteString("<h3>Options</h3>\n")
+		buf.WriteString(htmlTable([]string{"Option", "Type", "Default", "Required", "Description"}, cells))
+	}
+
+	if args := c.Args(); len(args) > 0 {
+		cells := make([][]string, 0, len(args))
+		for _, arg := range args {
+			required := "No"
+			if arg.Required {
+				required = "Yes"
+			}
+			cells = append(cells, []string{"<code>" + esc(argName(arg)) + "</code>", required, esc(cleanLine(renderText(c, arg.Desc)))})
+		}
+		buf.WriteString("<h3>Arguments</h3>\n")
+		buf.WriteString(htmlTable([]string{"Argument", "Required", "Description"}, cells))
+	}
+
+	if c.Examples != "" {
+		buf.WriteString("<h3>Examples</h3>\n<pre><code>" + esc(examplesText(c)) + "</code></pre>\n")
+	}
+
+	if subs := c.Commands(); len(subs) > 0 {
+		buf.WriteString("<h3>SubCommands</h3>\n<ul>\n")
+		for _, sub := range sortedSubs(subs) {
+			buf.WriteString(`<li><a href="#` + cmdAnchor(sub) + `">` + esc(sub.Name) + "</a> - " + esc(cmdDesc(sub)) + "</li>\n")
+		}
+		buf.WriteString("</ul>\n")
+	}
+
+	buf.WriteString("</section>\n")
+	return buf.String()
+}
+
+// htmlNavTree 渲染侧边栏的命令树(嵌套 ul, 链接到命令锚点)
+func htmlNavTree(cmds map[string]*gcli.Command) string {
+	if len(cmds) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<ul>\n")
+	for _, c := range sortedSubs(cmds) {
+		sb.WriteString(`<li><a href="#` + cmdAnchor(c) + `">` + html.EscapeString(c.Name) + "</a>")
+		sb.WriteString(htmlNavTree(c.Commands()))
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+	return sb.String()
+}
+
+// AppHTML 渲染应用为单页静态 HTML: 左侧命令树侧边栏, 右侧为应用概览与全部命令(带锚点)。
+func AppHTML(app *gcli.App) string {
+	var buf strings.Builder
+	esc := html.EscapeString
+	cmds := app.Commands()
+
+	buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	buf.WriteString(`<meta name="viewport" content="width=device-width, initial-scale=1">` + "\n")
+	buf.WriteString("<title>" + esc(app.Name) + "</title>\n<style>\n" + htmlStyle + "\n</style>\n</head>\n<body>\n")
+
+	// 侧边栏命令树
+	buf.WriteString("<nav>\n<strong><a href=\"#top\">" + esc(app.Name) + "</a></strong>\n")
+	buf.WriteString(htmlNavTree(cmds))
+	buf.WriteString("</nav>\n<main>\n")
+
+	// 应用概览
+	buf.WriteString(`<section id="top">` + "\n<h1>" + esc(app.Name) + "</h1>\n")
+	if app.Desc != "" {
+		buf.WriteString("<p>" + esc(cleanLine(color.ClearTag(app.ReplacePairs(app.Desc)))) + "</p>\n")
+	}
+	if app.Version != "" {
+		buf.WriteString("<p><strong>Version</strong>: " + esc(app.Version) + "</p>\n")
+	}
+	if len(cmds) > 0 {
+		buf.WriteString("<h3>Commands</h3>\n<ul>\n")
+		for _, c := range sortedSubs(cmds) {
+			buf.WriteString(`<li><a href="#` + cmdAnchor(c) + `">` + esc(c.Name) + "</a> - " + esc(cmdDesc(c)) + "</li>\n")
+		}
+		buf.WriteString("</ul>\n")
+	}
+	buf.WriteString("</section>\n")
+
+	// 全部命令(深度优先)
+	walkCmds(cmds, func(c *gcli.Command) {
+		buf.WriteString(CmdHTML(c))
+	})
+
+	buf.WriteString("<footer>Auto generated by gcli docgen.</footer>\n</main>\n</body>\n</html>\n")
+	return buf.String()
+}
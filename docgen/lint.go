@@ -0,0 +1,82 @@
+package docgen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// roffMacros 允许出现在行首的 man(7) 宏与常用 roff 请求
+var roffMacros = map[string]bool{
+	// man(7) macros
+	"TH": true, "SH": true, "SS": true, "TP": true, "TQ": true, "PP": true, "P": true, "LP": true,
+	"IP": true, "HP": true, "RS": true, "RE": true, "EX": true, "EE": true, "UR": true, "UE": true,
+	"MT": true, "ME": true, "SY": true, "YS": true, "OP": true,
+	// font macros
+	"B": true, "I": true, "SM": true, "SB": true, "BI": true, "BR": true,
+	"IB": true, "IR": true, "RB": true, "RI": true,
+	// roff requests
+	"br": true, "sp": true, "nf": true, "fi": true, "ad": true, "na": true, "in": true, "ti": true,
+	"ne": true, "hy": true, "nh": true, "ft": true, "ds": true, "nr": true, "so": true, "ie": true,
+	"el": true, "if": true, "de": true, "ll": true, "bp": true, "ce": true, "ig": true,
+}
+
+// LintMan 检查 man page(roff) 内容的常见错误, 返回全部问题(按行号), 无问题返回 nil。
+//
+// 检查项:
+//   - 缺少 .TH 头
+//   - .nf/.fi 不成对(嵌套的 .nf, 多余的 .fi, 未关闭的 .nf)
+//   - 未转义的行首 `.` 或 `'`(未知宏), 文本行应使用 `\&` 保护, see protectLeading
+func LintMan(doc string) error {
+	var errs []error
+	addErr := func(line int, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("line %d: "+format, append([]any{line}, args...)...))
+	}
+
+	var hasTH bool
+	nfLine := 0 // .nf 所在行, 0 表示不在 .nf 区块内
+	for i, line := range strings.Split(doc, "\n") {
+		lineNo := i + 1
+		if line == "" || (line[0] != '.' && line[0] != '\'') {
+			continue
+		}
+
+		req := strings.TrimSpace(line[1:])
+		// 注释 `.\"` 与空请求 `.`
+		if req == "" || strings.HasPrefix(req, `\"`) {
+			continue
+		}
+
+		name := req
+		if idx := strings.IndexAny(req, " \t"); idx > 0 {
+			name = req[:idx]
+		}
+
+		switch name {
+		case "TH":
+			hasTH = true
+		case "nf":
+			if nfLine > 0 {
+				addErr(lineNo, "nested .nf, the .nf at line %d is not closed", nfLine)
+			}
+			nfLine = lineNo
+		case "fi":
+			if nfLine == 0 {
+				addErr(lineNo, "unbalanced .fi without .nf")
+			}
+			nfLine = 0
+		default:
+			if !roffMacros[name] {
+				addErr(lineNo, "unknown macro or unescaped leading %q: %s", line[:1], line)
+			}
+		}
+	}
+
+	if nfLine > 0 {
+		addErr(nfLine, "unclosed .nf, missing .fi")
+	}
+	if !hasTH {
+		errs = append([]error{errors.New("missing .TH header")}, errs...)
+	}
+	return errors.Join(errs...)
+}
@@ -1,10 +1,11 @@
 package docgen
 
 import (
-	"os"
-	"path/filepath"
+	"fmt"
+	"path"
 	"strings"
 
+	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3"
 )
 
@@ -37,15 +38,28 @@ func roffRawLine(s string) string {
 }
 
 // CmdMan 渲染单个命令为 man page(roff 格式)。
-func CmdMan(c *gcli.Command) string {
+func CmdMan(c *gcli.Command) string { return CmdManCustom(c, nil) }
+
+// CmdManCustom 渲染单个命令为 man page, 设置了 opts.FileName 时页面名(标题, NAME, SEE ALSO 引用)
+// 与文件名一致。eg: 使用 PrefixedFileName 时为 `myapp-remote-add`
+func CmdManCustom(c *gcli.Command, opts *Options) string {
 	var buf strings.Builder
+	meta := cmdMeta(c, true)
+
+	// 页面标题与 NAME 中的名称
+	title, name := c.Name, c.Path()
+	if opts != nil && opts.FileName != nil {
+		title = manPageName(c, opts)
+		name = title
+	}
 
-	// .TH 头: 标题(命令名大写) section date source manual
-	buf.WriteString(".TH \"" + strings.ToUpper(escapeRoff(c.Name)) + "\" \"1\" \"\" \"\" \"\"\n")
+	// .TH 头: 标题(大写) section date source manual
+	buf.WriteString(fmt.Sprintf(".TH %s \"1\" %s %s %s\n", roffQuote(strings.ToUpper(title)),
+		roffQuote(meta.manDate()), roffQuote(meta.Source), roffQuote(meta.Manual)))
 
 	// NAME
 	buf.WriteString(".SH NAME\n")
-	buf.WriteString(roffLine(c.Path()) + " \\- " + roffLine(renderText(c, c.Desc)) + "\n")
+	buf.WriteString(roffLine(name) + " \\- " + roffLine(renderText(c, c.Desc)) + "\n")
 
 	// SYNOPSIS
 	buf.WriteString(".SH SYNOPSIS\n")
@@ -57,19 +71,25 @@ func CmdMan(c *gcli.Command) string {
 		buf.WriteString(roffLine(renderText(c, c.Help)) + "\n")
 	}
 
+	// DEPRECATED(弃用提示)
+	if c.Deprecated != "" {
+		buf.WriteString(".SH DEPRECATED\n")
+		buf.WriteString(roffLine(c.Deprecated) + "\n")
+	}
+
 	// OPTIONS(跳过 Hidden)
-	opts := c.Opts()
-	if hasVisibleOpts(opts) {
+	cmdOpts := c.Opts()
+	if hasVisibleOpts(cmdOpts) {
 		buf.WriteString(".SH OPTIONS\n")
-		for _, name := range sortedOptNames(opts) {
-			opt := opts[name]
+		for _, name := range sortedOptNames(cmdOpts) {
+			opt := cmdOpts[name]
 			if opt.Hidden {
 				continue
 			}
 			buf.WriteString(".TP\n")
 			// 选项名转义, eg: --name -> \-\-name
 			buf.WriteString("\\fB" + escapeRoff(optHelpName(opt)) + "\\fR\n")
-			buf.WriteString(roffLine(renderText(c, opt.Desc)) + "\n")
+			buf.WriteString(roffLine(optDesc(c, opt)) + "\n")
 		}
 	}
 
@@ -88,6 +108,10 @@ func CmdMan(c *gcli.Command) string {
 		}
 	}
 
+	writeManItems(&buf, "EXIT STATUS", meta.ExitCodes)
+	writeManItems(&buf, "ENVIRONMENT", meta.EnvVars)
+	writeManItems(&buf, "FILES", meta.Files)
+
 	// EXAMPLES(用 .nf/.fi 关闭填充并逐行输出, 保留多行原样)
 	if c.Examples != "" {
 		buf.WriteString(".SH EXAMPLES\n")
@@ -98,34 +122,142 @@ func CmdMan(c *gcli.Command) string {
 		buf.WriteString(".fi\n")
 	}
 
+	// 顶层命令引用应用页面 {app}(1)
+	var refs []string
+	if c.Parent() == nil && c.App() != nil {
+		refs = append(refs, manRef(c.App().Name))
+	}
+	for _, rc := range meta.related {
+		refs = append(refs, manRef(manPageName(rc, opts)))
+	}
+
+	writeManFooter(&buf, meta, refs)
 	return buf.String()
 }
 
-// ManTree 在 dir 下为每个命令(含子命令递归)写一个 `.1` 文件(命名同 markdown, 扩展名 .1)。
-func ManTree(app *gcli.App, dir string) error {
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
+// manPageName 命令的 man page 名, 与文件名一致(不含目录)
+func manPageName(c *gcli.Command, opts *Options) string {
+	return path.Base(opts.fileName(c))
+}
+
+// manRef man page 引用, eg: \fBdemo_child\fR(1)
+func manRef(name string) string { return "\\fB" + escapeRoff(name) + "\\fR(1)" }
+
+// PrefixedFileName 以应用名为前缀、`-` 连接的文件名策略, 适合安装到系统 man 目录。
+// eg: `myapp remote add` -> `myapp-remote-add`
+func PrefixedFileName(c *gcli.Command) string {
+	name := strings.ReplaceAll(c.Path(), " ", "-")
+	if c.App() != nil && c.App().Name != "" {
+		return c.App().Name + "-" + name
 	}
+	return name
+}
 
-	for _, c := range app.Commands() {
-		if err := writeCmdMan(c, dir); err != nil {
-			return err
-		}
+// AppMan 渲染应用的 man page: 应用概览与全部命令(含子命令)列表, 并引用各命令页面。
+func AppMan(app *gcli.App) string { return AppManCustom(app, nil) }
+
+// AppManCustom 渲染应用的 man page, 命令页面名由 opts.FileName 决定。see CmdManCustom
+func AppManCustom(app *gcli.App, opts *Options) string {
+	var buf strings.Builder
+	meta := appMeta(app)
+
+	buf.WriteString(fmt.Sprintf(".TH %s \"1\" %s %s %s\n", roffQuote(strings.ToUpper(app.Name)),
+		roffQuote(meta.manDate()), roffQuote(meta.Source), roffQuote(meta.Manual)))
+
+	buf.WriteString(".SH NAME\n")
+	buf.WriteString(roffLine(app.Name) + " \\- " + roffLine(color.ClearTag(app.ReplacePairs(app.Desc))) + "\n")
+
+	buf.WriteString(".SH SYNOPSIS\n")
+	buf.WriteString(roffLine(app.Name) + " <command> [\\-\\-options ...] [arguments ...]\n")
+
+	// COMMANDS: 全部可见命令(深度优先), 并引用各自的页面
+	var refs []string
+	cmds := app.Commands()
+	if len(cmds) > 0 {
+		buf.WriteString(".SH COMMANDS\n")
+		walkCmds(cmds, func(c *gcli.Command) {
+			if !c.Visible() {
+				return
+			}
+			name := manPageName(c, opts)
+			refs = append(refs, manRef(name))
+
+			buf.WriteString(".TP\n")
+			buf.WriteString("\\fB" + escapeRoff(c.Path()) + "\\fR\n")
+			buf.WriteString(roffLine(cmdDesc(c)) + " (see " + manRef(name) + ")\n")
+		})
 	}
-	return nil
+
+	writeManItems(&buf, "EXIT STATUS", meta.ExitCodes)
+	writeManItems(&buf, "ENVIRONMENT", meta.EnvVars)
+	writeManItems(&buf, "FILES", meta.Files)
+	writeManFooter(&buf, meta, refs)
+	return buf.String()
 }
 
-// writeCmdMan 递归写单个命令及其子命令的 man 文件。
-func writeCmdMan(c *gcli.Command, dir string) error {
-	file := filepath.Join(dir, cmdFileName(c)+".1")
-	if err := os.WriteFile(file, []byte(CmdMan(c)), 0644); err != nil {
-		return err
+// NewManRenderer 创建 man page 渲染器: 每个命令一个页面, 另写应用页面 `{app}.1`(IndexName 为应用名)。
+func NewManRenderer(opts *Options) Renderer {
+	return &FuncRenderer{
+		FileExt: ".1",
+		AppFn:   func(app *gcli.App) string { return AppManCustom(app, opts) },
+		CmdFn:   func(c *gcli.Command) string { return CmdManCustom(c, opts) },
+		IndexFn: func(app *gcli.App) string { return app.Name },
+	}
+}
+
+// roffQuote 转义并加引号, 用于宏参数(如 .TH)。参数内的双引号替换为 \(dq
+func roffQuote(s string) string {
+	return `"` + strings.ReplaceAll(escapeRoff(cleanLine(s)), `"`, `\(dq`) + `"`
+}
+
+// writeManItems 以 .TP 列表写一个章节, items 为空时不输出。
+func writeManItems(buf *strings.Builder, title string, items []gcli.DocItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	buf.WriteString(".SH " + title + "\n")
+	for _, item := range items {
+		buf.WriteString(".TP\n")
+		buf.WriteString("\\fB" + escapeRoff(cleanLine(item.Name)) + "\\fR\n")
+		buf.WriteString(roffLine(item.Desc) + "\n")
 	}
+}
 
-	for _, sub := range c.Commands() {
-		if err := writeCmdMan(sub, dir); err != nil {
-			return err
+// writeManFooter 写 AUTHORS, REPORTING BUGS 与 SEE ALSO 章节, refs 为相关页面的引用。
+func writeManFooter(buf *strings.Builder, meta *docMeta, refs []string) {
+	if len(meta.Authors) > 0 {
+		buf.WriteString(".SH AUTHORS\n")
+		for i, author := range meta.Authors {
+			if i > 0 {
+				buf.WriteString(".br\n")
+			}
+			buf.WriteString(roffLine(author) + "\n")
 		}
 	}
-	return nil
+
+	if meta.BugURL != "" {
+		buf.WriteString(".SH REPORTING BUGS\n")
+		buf.WriteString("Report bugs to: " + roffLine(meta.BugURL) + "\n")
+	}
+
+	for _, ref := range meta.SeeAlso {
+		refs = append(refs, escapeRoff(cleanLine(ref)))
+	}
+	if len(refs) > 0 {
+		buf.WriteString(".SH SEE ALSO\n")
+		buf.WriteString(protectLeading(strings.Join(refs, ", ")) + "\n")
+	}
+}
+
+// ManTree 在 dir 下为每个命令(含子命令递归)写一个 `.1` 文件(命名同 markdown, 扩展名 .1), 另写应用页面 `{app}.1`。
+func ManTree(app *gcli.App, dir string) error { return Tree(app, dir, Man) }
+
+// ManTreeCustom 同 ManTree, 但使用 opts 自定义文件名(页面名)与前置内容。
+//
+// Usage:
+//
+//	docgen.ManTreeCustom(app, dir, &docgen.Options{FileName: docgen.PrefixedFileName})
+func ManTreeCustom(app *gcli.App, dir string, opts *Options) error {
+	return TreeCustom(app, dir, NewManRenderer(opts), opts)
 }
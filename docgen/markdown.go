@@ -1,12 +1,11 @@
-// Package docgen 提供把 gcli 命令/应用导出为 markdown 与 man page(roff) 文档的能力。
+// Package docgen 提供把 gcli 命令/应用导出为 markdown, man page(roff), reStructuredText,
+// AsciiDoc 与单页 HTML 文档的能力, 并可通过 Renderer 接口注册自定义格式。
 //
 // 对标 cobra 的 cobra/doc(GenMarkdownTree/GenManTree)，纯新增、零破坏，仅依赖 gcli 公开内省 API。
 package docgen
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 
@@ -56,7 +55,7 @@ func sortedOptNames(opts map[string]*gflag.CliOpt) []string {
 	return names
 }
 
-// optHelpName 渲染选项名单元: 短名在前(逗号分隔), 长名 `--name`。eg: `-n, --name`。
+// optHelpName 渲染选项名单元: 短名在前(逗号分隔), 长名 `--name`, 长别名在后。eg: `-n, --name, --nm`。
 func optHelpName(opt *gflag.CliOpt) string {
 	var sb strings.Builder
 	for _, s := range opt.Shorts {
@@ -66,11 +65,30 @@ func optHelpName(opt *gflag.CliOpt) string {
 	}
 	sb.WriteString("--")
 	sb.WriteString(opt.Name)
+	for _, alias := range opt.Aliases {
+		sb.WriteString(", --")
+		sb.WriteString(alias)
+	}
 	return sb.String()
 }
 
+// optDesc 渲染选项描述, 弃用选项追加弃用说明, 并列出已弃用的旧名称。
+func optDesc(c *gcli.Command, opt *gflag.CliOpt) string {
+	desc := renderText(c, opt.Desc)
+	if opt.Deprecated != "" {
+		desc = "(deprecated: " + opt.Deprecated + ") " + desc
+	}
+	if len(opt.DeprecatedNames) > 0 {
+		desc += " (deprecated names: --" + strings.Join(opt.DeprecatedNames, ", --") + ")"
+	}
+	return desc
+}
+
 // CmdMarkdown 渲染单个命令为 markdown 文档(cobra 风格)。
-func CmdMarkdown(c *gcli.Command) string {
+func CmdMarkdown(c *gcli.Command) string { return CmdMarkdownCustom(c, nil) }
+
+// CmdMarkdownCustom 渲染单个命令为 markdown 文档, 使用 opts 中的链接与页脚钩子。
+func CmdMarkdownCustom(c *gcli.Command, opts *Options) string {
 	var buf strings.Builder
 
 	// 标题 + 描述
@@ -78,6 +96,10 @@ func CmdMarkdown(c *gcli.Command) string {
 	if c.Desc != "" {
 		buf.WriteString(cleanLine(renderText(c, c.Desc)) + "\n\n")
 	}
+	// 弃用提示
+	if c.Deprecated != "" {
+		buf.WriteString("> **Deprecated**: " + cleanLine(c.Deprecated) + "\n\n")
+	}
 
 	// 长帮助 -> Synopsis
 	if c.Help != "" {
@@ -96,13 +118,13 @@ func CmdMarkdown(c *gcli.Command) string {
 	}
 
 	// Options 表(跳过 Hidden 选项)
-	opts := c.Opts()
-	if hasVisibleOpts(opts) {
+	cmdOpts := c.Opts()
+	if hasVisibleOpts(cmdOpts) {
 		buf.WriteString("## Options\n\n")
 		buf.WriteString("Option | Type | Default | Required | Description\n")
 		buf.WriteString("-------|------|---------|----------|------------\n")
-		for _, name := range sortedOptNames(opts) {
-			opt := opts[name]
+		for _, name := range sortedOptNames(cmdOpts) {
+			opt := cmdOpts[name]
 			if opt.Hidden {
 				continue
 			}
@@ -115,7 +137,7 @@ func CmdMarkdown(c *gcli.Command) string {
 				escapeTableCell(opt.TypeName()),
 				escapeTableCell(opt.DefaultText()),
 				required,
-				escapeTableCell(renderText(c, opt.Desc)),
+				escapeTableCell(optDesc(c, opt)),
 			))
 		}
 		buf.WriteString("\n")
@@ -151,27 +173,83 @@ func CmdMarkdown(c *gcli.Command) string {
 		buf.WriteString("```\n\n")
 	}
 
+	meta := cmdMeta(c, false)
+	writeMdItems(&buf, "Environment", meta.EnvVars)
+	writeMdItems(&buf, "Exit Status", meta.ExitCodes)
+	writeMdItems(&buf, "Files", meta.Files)
+
 	// SubCommands -> 相对链接(文件名与 MarkdownTree 一致)
 	subs := c.Commands()
 	if len(subs) > 0 {
 		buf.WriteString("## SubCommands\n\n")
-		names := make([]string, 0, len(subs))
-		for name := range subs {
-			names = append(names, name)
-		}
-		sort.Strings(names)
-		for _, name := range names {
-			sub := subs[name]
-			buf.WriteString(fmt.Sprintf("- [%s](%s.md) - %s\n",
-				sub.Name, cmdFileName(sub), cleanLine(renderText(sub, sub.Desc))))
+		for _, sub := range sortedSubs(subs) {
+			buf.WriteString(fmt.Sprintf("- [%s](%s) - %s\n",
+				sub.Name, opts.link(c, sub, ".md"), cmdDesc(sub)))
 		}
 		buf.WriteString("\n")
 	}
 
-	buf.WriteString(genTip + "\n")
+	writeMdMetaFooter(&buf, meta, func(rc *gcli.Command) string { return opts.link(c, rc, ".md") })
+	writeFooter(&buf, opts)
 	return buf.String()
 }
 
+// writeMdItems 以列表写一个章节, items 为空时不输出。
+func writeMdItems(buf *strings.Builder, title string, items []gcli.DocItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	buf.WriteString("## " + title + "\n\n")
+	for _, item := range items {
+		buf.WriteString("- `" + cleanLine(item.Name) + "` - " + cleanLine(item.Desc) + "\n")
+	}
+	buf.WriteString("\n")
+}
+
+// writeMdMetaFooter 写 See Also, Authors 与 Reporting Bugs 章节, linkFn 计算相关命令文档的链接。
+func writeMdMetaFooter(buf *strings.Builder, meta *docMeta, linkFn func(rc *gcli.Command) string) {
+	if len(meta.related) > 0 || len(meta.SeeAlso) > 0 {
+		buf.WriteString("## See Also\n\n")
+		for _, rc := range meta.related {
+			buf.WriteString(fmt.Sprintf("- [%s](%s) - %s\n", rc.Path(), linkFn(rc), cmdDesc(rc)))
+		}
+		for _, ref := range meta.SeeAlso {
+			buf.WriteString("- " + cleanLine(ref) + "\n")
+		}
+		buf.WriteString("\n")
+	}
+
+	if len(meta.Authors) > 0 {
+		buf.WriteString("## Authors\n\n")
+		for _, author := range meta.Authors {
+			buf.WriteString("- " + cleanLine(author) + "\n")
+		}
+		buf.WriteString("\n")
+	}
+
+	if meta.BugURL != "" {
+		buf.WriteString("## Reporting Bugs\n\n")
+		buf.WriteString("Report bugs to: " + meta.BugURL + "\n\n")
+	}
+}
+
+// writeFooter 写页脚(可被 opts 替换或禁用)
+func writeFooter(buf *strings.Builder, opts *Options) {
+	if footer := opts.footer(); footer != "" {
+		buf.WriteString(footer + "\n")
+	}
+}
+
+// cmdDesc 渲染命令列表中的单行描述, 弃用命令追加 `(deprecated)` 标记。
+func cmdDesc(c *gcli.Command) string {
+	desc := cleanLine(renderText(c, c.Desc))
+	if c.Deprecated != "" {
+		desc += " (deprecated)"
+	}
+	return desc
+}
+
 // hasVisibleOpts 判断是否存在非 Hidden 选项。
 func hasVisibleOpts(opts map[string]*gflag.CliOpt) bool {
 	for _, opt := range opts {
@@ -183,7 +261,10 @@ func hasVisibleOpts(opts map[string]*gflag.CliOpt) bool {
 }
 
 // AppMarkdown 渲染应用概览文档: 标题/描述 + 顶层命令列表(含相对链接)。
-func AppMarkdown(app *gcli.App) string {
+func AppMarkdown(app *gcli.App) string { return AppMarkdownCustom(app, nil) }
+
+// AppMarkdownCustom 渲染应用概览文档, 使用 opts 中的链接与页脚钩子。
+func AppMarkdownCustom(app *gcli.App, opts *Options) string {
 	var buf strings.Builder
 
 	buf.WriteString("# " + app.Name + "\n\n")
@@ -198,55 +279,32 @@ func AppMarkdown(app *gcli.App) string {
 	cmds := app.Commands()
 	if len(cmds) > 0 {
 		buf.WriteString("## Commands\n\n")
-		names := make([]string, 0, len(cmds))
-		for name := range cmds {
-			names = append(names, name)
-		}
-		sort.Strings(names)
-		for _, name := range names {
-			c := cmds[name]
-			buf.WriteString(fmt.Sprintf("- [%s](%s.md) - %s\n",
-				name, cmdFileName(c), cleanLine(renderText(c, c.Desc))))
+		for _, c := range sortedSubs(cmds) {
+			buf.WriteString(fmt.Sprintf("- [%s](%s) - %s\n",
+				c.Name, opts.link(nil, c, ".md"), cmdDesc(c)))
 		}
 		buf.WriteString("\n")
 	}
 
-	buf.WriteString(genTip + "\n")
+	meta := appMeta(app)
+	writeMdItems(&buf, "Environment", meta.EnvVars)
+	writeMdItems(&buf, "Exit Status", meta.ExitCodes)
+	writeMdItems(&buf, "Files", meta.Files)
+	writeMdMetaFooter(&buf, meta, nil)
+
+	writeFooter(&buf, opts)
 	return buf.String()
 }
 
 // MarkdownTree 在 dir 下为每个命令(含子命令递归)写一个 `.md`, 另写 `index.md`(= AppMarkdown)。
-func MarkdownTree(app *gcli.App, dir string) error {
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
-
-	// 写应用概览
-	indexFile := filepath.Join(dir, "index.md")
-	if err := os.WriteFile(indexFile, []byte(AppMarkdown(app)), 0644); err != nil {
-		return err
-	}
+func MarkdownTree(app *gcli.App, dir string) error { return Tree(app, dir, Markdown) }
 
-	// 递归写每个命令文档
-	for _, c := range app.Commands() {
-		if err := writeCmdMarkdown(c, dir); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// writeCmdMarkdown 递归写单个命令及其子命令的 markdown 文件。
-func writeCmdMarkdown(c *gcli.Command, dir string) error {
-	file := filepath.Join(dir, cmdFileName(c)+".md")
-	if err := os.WriteFile(file, []byte(CmdMarkdown(c)), 0644); err != nil {
-		return err
-	}
-
-	for _, sub := range c.Commands() {
-		if err := writeCmdMarkdown(sub, dir); err != nil {
-			return err
-		}
+// MarkdownTreeCustom 同 MarkdownTree, 但使用 opts 自定义文件名、front matter、链接与页脚。see Options
+func MarkdownTreeCustom(app *gcli.App, dir string, opts *Options) error {
+	r := &FuncRenderer{
+		FileExt: ".md",
+		AppFn:   func(app *gcli.App) string { return AppMarkdownCustom(app, opts) },
+		CmdFn:   func(c *gcli.Command) string { return CmdMarkdownCustom(c, opts) },
 	}
-	return nil
+	return TreeCustom(app, dir, r, opts)
 }
@@ -0,0 +1,163 @@
+package docgen
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gookit/gcli/v3"
+)
+
+// docMeta 解析后的文档元数据: 命令自身的值优先于应用, 并补充可推导的值(选项 ENV 绑定, 相关命令)。
+type docMeta struct {
+	gcli.DocMeta
+	// related 相关命令(父命令, 兄弟命令, 可选子命令), 渲染到 SEE ALSO
+	related []*gcli.Command
+}
+
+// cmdMeta 解析命令的文档元数据。withSubs 为 true 时相关命令包含子命令。
+func cmdMeta(c *gcli.Command, withSubs bool) *docMeta {
+	m := &docMeta{DocMeta: c.DocMeta}
+
+	var app gcli.DocMeta
+	if c.App() != nil {
+		app = c.App().DocMeta
+	}
+	// 选项的 ENV 绑定排在显式设置的之后, eg: default value "${APP_TOKEN}"
+	m.EnvVars = mergeDocItems(m.EnvVars, optEnvVars(c), app.EnvVars)
+	m.ExitCodes = sortExitCodes(mergeDocItems(m.ExitCodes, app.ExitCodes))
+	m.Files = mergeDocItems(m.Files, app.Files)
+	m.SeeAlso = mergeStrings(m.SeeAlso, app.SeeAlso)
+	if len(m.Authors) == 0 {
+		m.Authors = app.Authors
+	}
+	m.BugURL = firstNonEmpty(m.BugURL, app.BugURL)
+	m.Date = firstNonEmpty(m.Date, app.Date)
+	m.Source = firstNonEmpty(m.Source, app.Source)
+	m.Manual = firstNonEmpty(m.Manual, app.Manual)
+	if m.Source == "" && c.App() != nil {
+		m.Source = strings.TrimSpace(c.App().Name + " " + c.App().Version)
+	}
+
+	// 相关命令: 父命令 + 兄弟命令(+ 子命令)
+	var siblings map[string]*gcli.Command
+	if p := c.Parent(); p != nil {
+		m.related = append(m.related, p)
+		siblings = p.Commands()
+	} else if c.App() != nil {
+		siblings = c.App().Commands()
+	}
+	for _, sib := range sortedSubs(siblings) {
+		if sib != c && sib.Visible() {
+			m.related = append(m.related, sib)
+		}
+	}
+	if withSubs {
+		for _, sub := range sortedSubs(c.Commands()) {
+			if sub.Visible() {
+				m.related = append(m.related, sub)
+			}
+		}
+	}
+	return m
+}
+
+// appMeta 解析应用的文档元数据
+func appMeta(app *gcli.App) *docMeta {
+	m := &docMeta{DocMeta: app.DocMeta}
+	m.ExitCodes = sortExitCodes(mergeDocItems(m.ExitCodes))
+	if m.Source == "" {
+		m.Source = strings.TrimSpace(app.Name + " " + app.Version)
+	}
+	return m
+}
+
+// optEnvVars 返回命令可见选项绑定的 ENV 变量
+func optEnvVars(c *gcli.Command) []gcli.DocItem {
+	opts := c.Opts()
+	var items []gcli.DocItem
+	for _, name := range sortedOptNames(opts) {
+		opt := opts[name]
+		if opt.Hidden {
+			continue
+		}
+		if env := opt.EnvVar(); env != "" {
+			items = append(items, gcli.DocItem{
+				Name: env,
+				Desc: fmt.Sprintf("default value of --%s: %s", opt.Name, cleanLine(renderText(c, opt.Desc))),
+			})
+		}
+	}
+	return items
+}
+
+// manDate man page 头部日期: 优先 meta.Date, 其次 $SOURCE_DATE_EPOCH(可复现构建), 最后为当前日期。
+func (m *docMeta) manDate() string {
+	if m.Date != "" {
+		return m.Date
+	}
+
+	now := time.Now()
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if sec, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			now = time.Unix(sec, 0).UTC()
+		}
+	}
+	return now.Format("Jan 2006")
+}
+
+// mergeDocItems 合并多组条目, 按 Name 去重(先出现的优先)
+func mergeDocItems(groups ...[]gcli.DocItem) []gcli.DocItem {
+	var list []gcli.DocItem
+	seen := make(map[string]bool)
+	for _, items := range groups {
+		for _, item := range items {
+			if !seen[item.Name] {
+				seen[item.Name] = true
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// sortExitCodes 按退出码数值升序排列, 非数字的保持原有顺序并排在后面
+func sortExitCodes(items []gcli.DocItem) []gcli.DocItem {
+	code := func(s string) int {
+		if n, err := strconv.Atoi(s); err == nil {
+			return n
+		}
+		return math.MaxInt
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return code(items[i].Name) < code(items[j].Name) })
+	return items
+}
+
+// mergeStrings 合并多组字符串并去重(保持顺序)
+func mergeStrings(groups ...[]string) []string {
+	var list []string
+	seen := make(map[string]bool)
+	for _, ss := range groups {
+		for _, s := range ss {
+			if !seen[s] {
+				seen[s] = true
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
+
+func firstNonEmpty(ss ...string) string {
+	for _, s := range ss {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
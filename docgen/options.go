@@ -0,0 +1,138 @@
+package docgen
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gookit/gcli/v3"
+)
+
+// Options 文档生成的自定义钩子, 对标 cobra 的 GenMarkdownTreeCustom。nil 表示全部使用默认行为。
+//
+// Usage(Hugo):
+//
+//	opts := &docgen.Options{
+//		IndexName: "_index",
+//		FileName:  docgen.NestedFileName,
+//		FilePrepender: func(file string, c *gcli.Command) string {
+//			if c == nil {
+//				return "---\ntitle: \"CLI reference\"\n---\n\n"
+//			}
+//			return fmt.Sprintf("---\ntitle: %q\nweight: %d\nslug: %s\n---\n\n", c.Path(), len(c.PathNames())*10, c.Name)
+//		},
+//		LinkHandler: func(c *gcli.Command, link string) string {
+//			return "/cli/" + strings.TrimSuffix(link, ".md") + "/"
+//		},
+//		Footer: "> Auto generated on {date}.",
+//	}
+//	err := docgen.MarkdownTreeCustom(app, "./content/cli", opts)
+type Options struct {
+	// IndexName 应用概览文档的文件名(不含扩展名), 默认 "index"。eg: Hugo 的 "_index"
+	IndexName string
+	// FileName 命令文档相对 dir 的路径(不含扩展名, 可含子目录), 默认为 `app_sub_cmd` 扁平命名。
+	// 可使用 NestedFileName 按命令层级生成子目录。
+	FileName func(c *gcli.Command) string
+	// FilePrepender 返回写在文档开头的内容, 如 front matter(title, weight, slug)。
+	// file 为文档相对 dir 的路径, c 为 nil 表示应用概览(index)文档。
+	FilePrepender func(file string, c *gcli.Command) string
+	// LinkHandler 转换指向命令文档的链接。link 为相对当前文档的文件路径, eg: "demo_child.md"
+	LinkHandler func(c *gcli.Command, link string) string
+	// Footer 替换默认的页脚 genTip, 支持变量 {date}(见 Date)。
+	Footer string
+	// DisableFooter 不输出页脚
+	DisableFooter bool
+	// Date 页脚中 {date} 的日期, 默认为当前时间。设置固定值可使生成结果可复现。
+	Date time.Time
+	// HeadingLevel 单文档模式(SingleMarkdown)中应用标题的级别, 默认 1。
+	// 嵌入 README 已有章节时可设为 2, 命令标题级别随之顺延。
+	HeadingLevel int
+}
+
+// NestedFileName 按命令层级生成子目录的文件名策略。eg: `remote add` -> `remote/add`
+func NestedFileName(c *gcli.Command) string {
+	return strings.ReplaceAll(c.Path(), " ", "/")
+}
+
+// indexName 应用概览文档的文件名(不含扩展名)
+func (o *Options) indexName() string {
+	if o == nil || o.IndexName == "" {
+		return "index"
+	}
+	return o.IndexName
+}
+
+// fileName 命令文档相对 dir 的路径(不含扩展名, 使用 `/` 分隔)
+func (o *Options) fileName(c *gcli.Command) string {
+	if o == nil || o.FileName == nil {
+		return cmdFileName(c)
+	}
+	return o.FileName(c)
+}
+
+// prepend 文档开头内容
+func (o *Options) prepend(file string, c *gcli.Command) string {
+	if o == nil || o.FilePrepender == nil {
+		return ""
+	}
+	return o.FilePrepender(file, c)
+}
+
+// link 计算从 from 命令文档(nil 为 index)指向 to 命令文档的链接
+func (o *Options) link(from, to *gcli.Command, ext string) string {
+	target := o.fileName(to) + ext
+
+	// 相对 from 文档所在目录
+	fromDir := "."
+	if from != nil {
+		fromDir = path.Dir(o.fileName(from))
+	}
+	link := relPath(fromDir, target)
+
+	if o != nil && o.LinkHandler != nil {
+		return o.LinkHandler(to, link)
+	}
+	return link
+}
+
+// headingLevel 单文档模式中应用标题的级别
+func (o *Options) headingLevel() int {
+	if o == nil || o.HeadingLevel < 1 {
+		return 1
+	}
+	return o.HeadingLevel
+}
+
+// footer 页脚内容, 为空表示不输出
+func (o *Options) footer() string {
+	if o == nil {
+		return genTip
+	}
+	if o.DisableFooter {
+		return ""
+	}
+	if o.Footer == "" {
+		return genTip
+	}
+
+	date := o.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return strings.ReplaceAll(o.Footer, "{date}", date.Format("2006-01-02"))
+}
+
+// relPath 计算 target 相对 base 目录的 slash 路径。eg: ("a", "a/b.md") -> "b.md", ("a/b", "c.md") -> "../../c.md"
+func relPath(base, target string) string {
+	base, target = path.Clean(base), path.Clean(target)
+	if base == "." {
+		return target
+	}
+
+	bs, ts := strings.Split(base, "/"), strings.Split(target, "/")
+	i := 0
+	for i < len(bs) && i < len(ts)-1 && bs[i] == ts[i] {
+		i++
+	}
+	return strings.Repeat("../", len(bs)-i) + strings.Join(ts[i:], "/")
+}
@@ -0,0 +1,280 @@
+package docgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/gflag"
+)
+
+// Renderer 文档渲染器: 把应用与命令渲染为某种格式的文档, 由 Tree 写入目录。
+//
+// 第三方可实现并通过 Register 注册自定义格式, builtin gendoc 的 `-f` 即可使用。
+type Renderer interface {
+	// Ext 文档文件扩展名, eg: ".md"
+	Ext() string
+	// AppDoc 渲染应用概览文档(写入 index 文件), 返回空则不写。
+	AppDoc(app *gcli.App) string
+	// CmdDoc 渲染单个命令文档(每个命令一个文件), 返回空则不写。
+	CmdDoc(c *gcli.Command) string
+}
+
+// IndexNamer 可选接口: 渲染器自定义应用概览文档的文件名(不含扩展名), 默认为 "index"。
+type IndexNamer interface {
+	IndexName(app *gcli.App) string
+}
+
+// FuncRenderer 以函数实现的 Renderer, nil 函数表示不生成对应文件。
+type FuncRenderer struct {
+	FileExt string
+	AppFn   func(app *gcli.App) string
+	CmdFn   func(c *gcli.Command) string
+	// IndexFn 应用概览文档的文件名(不含扩展名), nil 为 "index"。see IndexNamer
+	IndexFn func(app *gcli.App) string
+}
+
+// Ext 文档文件扩展名
+func (r *FuncRenderer) Ext() string { return r.FileExt }
+
+// IndexName 应用概览文档的文件名
+func (r *FuncRenderer) IndexName(app *gcli.App) string {
+	if r.IndexFn == nil {
+		return "index"
+	}
+	return r.IndexFn(app)
+}
+
+// AppDoc 渲染应用概览文档
+func (r *FuncRenderer) AppDoc(app *gcli.App) string {
+	if r.AppFn == nil {
+		return ""
+	}
+	return r.AppFn(app)
+}
+
+// CmdDoc 渲染单个命令文档
+func (r *FuncRenderer) CmdDoc(c *gcli.Command) string {
+	if r.CmdFn == nil {
+		return ""
+	}
+	return r.CmdFn(c)
+}
+
+// built-in renderers
+var (
+	// Markdown 每个命令一个 .md, 另写 index.md
+	Markdown Renderer = &FuncRenderer{FileExt: ".md", AppFn: AppMarkdown, CmdFn: CmdMarkdown}
+	// Man 每个命令一个 man page(.1), 另写链接全部命令的 `{app}.1`
+	Man Renderer = NewManRenderer(nil)
+	// RST reStructuredText(用于 Sphinx), 每个命令一个 .rst, index.rst 含 toctree
+	RST Renderer = &FuncRenderer{FileExt: ".rst", AppFn: AppRST, CmdFn: CmdRST}
+	// AsciiDoc 每个命令一个 .adoc, 另写 index.adoc
+	AsciiDoc Renderer = &FuncRenderer{FileExt: ".adoc", AppFn: AppAsciiDoc, CmdFn: CmdAsciiDoc}
+	// HTML 单页静态站点(index.html), 含命令树侧边栏与锚点
+	HTML Renderer = &FuncRenderer{FileExt: ".html", AppFn: AppHTML}
+)
+
+var (
+	rmu sync.RWMutex
+	// format name => renderer
+	renderers = map[string]Renderer{}
+	// format alias => format name
+	formatAliases = map[string]string{}
+)
+
+func init() {
+	Register("md", Markdown, "markdown", "mkdown")
+	Register("man", Man)
+	Register("rst", RST, "rest")
+	Register("adoc", AsciiDoc, "asciidoc")
+	Register("html", HTML)
+}
+
+// Register 注册(或覆盖)文档格式渲染器, aliases 为格式别名。
+//
+// Usage:
+//
+//	docgen.Register("txt", &docgen.FuncRenderer{FileExt: ".txt", CmdFn: myCmdText})
+func Register(format string, r Renderer, aliases ...string) {
+	rmu.Lock()
+	defer rmu.Unlock()
+
+	renderers[format] = r
+	for _, alias := range aliases {
+		formatAliases[alias] = format
+	}
+}
+
+// Unregister 移除文档格式渲染器及其别名, 格式未注册时忽略。
+func Unregister(format string) {
+	rmu.Lock()
+	defer rmu.Unlock()
+
+	delete(renderers, format)
+	for alias, name := range formatAliases {
+		if name == format {
+			delete(formatAliases, alias)
+		}
+	}
+}
+
+// Get 按格式名或别名获取渲染器
+func Get(format string) (Renderer, bool) {
+	rmu.RLock()
+	defer rmu.RUnlock()
+
+	if name, ok := formatAliases[format]; ok {
+		format = name
+	}
+	r, ok := renderers[format]
+	return r, ok
+}
+
+// Formats 返回已注册的格式名(已排序, 不含别名)
+func Formats() []string {
+	rmu.RLock()
+	defer rmu.RUnlock()
+
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Generate 按格式名生成文档到 dir, 格式未注册时返回 error。
+func Generate(app *gcli.App, format, dir string) error {
+	r, ok := Get(format)
+	if !ok {
+		return fmt.Errorf("invalid format %q, allow: %s", format, strings.Join(Formats(), ", "))
+	}
+	return Tree(app, dir, r)
+}
+
+// Tree 用渲染器在 dir 下写 index 文档(AppDoc)及每个命令(含子命令递归)的文档(CmdDoc)。
+//
+// 命令文件名规则见 cmdFileName, 扩展名为 r.Ext()。
+func Tree(app *gcli.App, dir string, r Renderer) error { return TreeCustom(app, dir, r, nil) }
+
+// TreeCustom 同 Tree, 但使用 opts 中的 index 文件名、命令文件名策略与文件前置内容。
+// 渲染器实现了 IndexNamer 且未设置 opts.IndexName 时, index 文件名由渲染器决定。
+//
+// NOTE: 渲染器的文档内容(链接/页脚)不受 opts 影响, markdown 请使用 MarkdownTreeCustom。
+func TreeCustom(app *gcli.App, dir string, r Renderer, opts *Options) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, page := range RenderPages(app, r, opts) {
+		file := filepath.Join(dir, filepath.FromSlash(page.File))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, []byte(page.Content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Page 渲染后的一个文档页
+type Page struct {
+	// File 文档相对输出目录的路径(使用 `/` 分隔), eg: "demo_child.md"
+	File string
+	// Cmd 文档对应的命令, nil 表示应用概览(index)文档
+	Cmd *gcli.Command
+	// Content 文档内容(已添加前置内容)
+	Content string
+}
+
+// RenderPages 渲染应用概览与全部命令的文档页但不写文件, 便于检查或自定义写入(如 gzip)。
+//
+// 文件名规则同 TreeCustom, 渲染结果为空的页会被跳过。
+func RenderPages(app *gcli.App, r Renderer, opts *Options) []Page {
+	var pages []Page
+	if doc := r.AppDoc(app); doc != "" {
+		name := opts.indexName()
+		if opts == nil || opts.IndexName == "" {
+			if in, ok := r.(IndexNamer); ok {
+				name = in.IndexName(app)
+			}
+		}
+
+		file := name + r.Ext()
+		pages = append(pages, Page{File: file, Content: opts.prepend(file, nil) + doc})
+	}
+
+	walkCmds(app.Commands(), func(c *gcli.Command) {
+		if doc := r.CmdDoc(c); doc != "" {
+			file := opts.fileName(c) + r.Ext()
+			pages = append(pages, Page{File: file, Cmd: c, Content: opts.prepend(file, c) + doc})
+		}
+	})
+	return pages
+}
+
+// optRow 选项表格的一行, 供各格式渲染复用。
+type optRow struct {
+	Name, Type, Default, Required, Desc string
+}
+
+// visibleOptRows 返回命令可见选项(已排序)的表格行, 各字段已去换行。
+func visibleOptRows(c *gcli.Command) []optRow { return optRowsOf(c, c.Opts(), nil) }
+
+// optRowsOf 返回 opts 中可见且未被 skip 过滤的选项(已排序)的表格行。
+func optRowsOf(c *gcli.Command, opts map[string]*gflag.CliOpt, skip func(opt *gflag.CliOpt) bool) []optRow {
+	rows := make([]optRow, 0, len(opts))
+	for _, name := range sortedOptNames(opts) {
+		opt := opts[name]
+		if opt.Hidden || (skip != nil && skip(opt)) {
+			continue
+		}
+
+		required := "No"
+		if opt.Required {
+			required = "Yes"
+		}
+		rows = append(rows, optRow{
+			Name:     optHelpName(opt),
+			Type:     cleanLine(opt.TypeName()),
+			Default:  cleanLine(opt.DefaultText()),
+			Required: required,
+			Desc:     cleanLine(optDesc(c, opt)),
+		})
+	}
+	return rows
+}
+
+// argName 参数展示名, 数组参数追加 `...`
+func argName(arg *gflag.CliArg) string {
+	if arg.Arrayed {
+		return arg.Name + "..."
+	}
+	return arg.Name
+}
+
+// sortedSubs 返回按名称排序的子命令
+func sortedSubs(cmds map[string]*gcli.Command) []*gcli.Command {
+	names := make([]string, 0, len(cmds))
+	for name := range cmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]*gcli.Command, 0, len(names))
+	for _, name := range names {
+		list = append(list, cmds[name])
+	}
+	return list
+}
+
+// examplesText 渲染示例文本(展开变量, 清除颜色标签, 去首尾空行)
+func examplesText(c *gcli.Command) string {
+	return strings.Trim(renderText(c, c.Examples), "\n")
+}
@@ -0,0 +1,165 @@
+package docgen
+
+import (
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3"
+)
+
+// escapeRST 转义 reStructuredText 行内标记字符(反斜杠, `*`, `_`, `|` 与反引号), 避免被解析为强调/引用/替换。
+func escapeRST(s string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		"*", "\\*",
+		"`", "\\`",
+		"_", "\\_",
+		"|", "\\|",
+	).Replace(s)
+}
+
+// rstTitle 渲染 RST 标题, 下划线长度不小于标题(按字节计, 多字节字符也足够)。
+func rstTitle(title string, ch byte) string {
+	return title + "\n" + strings.Repeat(string(ch), len(title)) + "\n\n"
+}
+
+// rstLiteral 渲染 RST 字面量块(`::` + 缩进), 保留多行原样。
+func rstLiteral(s string) string {
+	var sb strings.Builder
+	sb.WriteString("::\n\n")
+	for _, line := range strings.Split(s, "\n") {
+		if line == "" {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString("    " + line + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// rstListTable 渲染 RST list-table, 单元格内容已转义。
+func rstListTable(header []string, rows [][]string) string {
+	var sb strings.Builder
+	sb.WriteString(".. list-table::\n   :header-rows: 1\n\n")
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			prefix := "     - "
+			if i == 0 {
+				prefix = "   * - "
+			}
+			sb.WriteString(strings.TrimRight(prefix+cell, " ") + "\n")
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// CmdRST 渲染单个命令为 reStructuredText 文档(用于 Sphinx)。
+func CmdRST(c *gcli.Command) string {
+	var buf strings.Builder
+
+	// 标题 + 描述
+	buf.WriteString(rstTitle(c.Path(), '='))
+	if c.Desc != "" {
+		buf.WriteString(escapeRST(cleanLine(renderText(c, c.Desc))) + "\n\n")
+	}
+	if c.Deprecated != "" {
+		buf.WriteString(".. warning::\n\n   Deprecated: " + escapeRST(cleanLine(c.Deprecated)) + "\n\n")
+	}
+
+	if c.Help != "" {
+		buf.WriteString(rstTitle("Synopsis", '-'))
+		buf.WriteString(escapeRST(renderText(c, c.Help)) + "\n\n")
+	}
+
+	// 用法
+	buf.WriteString(rstLiteral(c.Path() + " [--options ...] [arguments ...]"))
+
+	if len(c.Aliases) > 0 {
+		buf.WriteString("**Aliases**: ``" + c.Aliases.String() + "``\n\n")
+	}
+
+	// Options
+	if rows := visibleOptRows(c); len(rows) > 0 {
+		cells := make([][]string, 0, len(rows))
+		for _, r := range rows {
+			cells = append(cells, []string{"``" + r.Name + "``", escapeRST(r.Type), escapeRST(r.Default), r.Required, escapeRST(r.Desc)})
+		}
+
+		buf.WriteString(rstTitle("Options", '-'))
+		buf.WriteString(rstListTable([]string{"Option", "Type", "Default", "Required", "Description"}, cells))
+	}
+
+	// Arguments
+	if args := c.Args(); len(args) > 0 {
+		cells := make([][]string, 0, len(args))
+		for _, arg := range args {
+			required := "No"
+			if arg.Required {
+				required = "Yes"
+			}
+			cells = append(cells, []string{"``" + argName(arg) + "``", required, escapeRST(cleanLine(renderText(c, arg.Desc)))})
+		}
+
+		buf.WriteString(rstTitle("Arguments", '-'))
+		buf.WriteString(rstListTable([]string{"Argument", "Required", "Description"}, cells))
+	}
+
+	if c.Examples != "" {
+		buf.WriteString(rstTitle("Examples", '-'))
+		buf.WriteString(rstLiteral(examplesText(c)))
+	}
+
+	// SubCommands -> :doc: 链接(文件名与 Tree 一致)
+	if subs := c.Commands(); len(subs) > 0 {
+		buf.WriteString(rstTitle("SubCommands", '-'))
+		for _, sub := range sortedSubs(subs) {
+			buf.WriteString("- :doc:`" + sub.Name + " <" + cmdFileName(sub) + ">` - " + escapeRST(cmdDesc(sub)) + "\n")
+		}
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString(".. note:: Auto generated by gcli docgen.\n")
+	return buf.String()
+}
+
+// AppRST 渲染应用概览 RST 文档: 标题/描述 + 顶层命令列表 + 全部命令的 toctree。
+func AppRST(app *gcli.App) string {
+	var buf strings.Builder
+
+	buf.WriteString(rstTitle(app.Name, '='))
+	if app.Desc != "" {
+		buf.WriteString(escapeRST(cleanLine(color.ClearTag(app.ReplacePairs(app.Desc)))) + "\n\n")
+	}
+	if app.Version != "" {
+		buf.WriteString("**Version**: " + escapeRST(app.Version) + "\n\n")
+	}
+
+	cmds := app.Commands()
+	if len(cmds) > 0 {
+		buf.WriteString(rstTitle("Commands", '-'))
+		for _, c := range sortedSubs(cmds) {
+			buf.WriteString("- :doc:`" + c.Name + " <" + cmdFileName(c) + ">` - " + escapeRST(cmdDesc(c)) + "\n")
+		}
+		buf.WriteString("\n")
+
+		// toctree 包含全部命令文档, Sphinx 才不会警告文档不在树中
+		buf.WriteString(".. toctree::\n   :hidden:\n\n")
+		walkCmds(cmds, func(c *gcli.Command) {
+			buf.WriteString("   " + cmdFileName(c) + "\n")
+		})
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString(".. note:: Auto generated by gcli docgen.\n")
+	return buf.String()
+}
+
+// walkCmds 按名称顺序深度优先遍历命令树
+func walkCmds(cmds map[string]*gcli.Command, fn func(c *gcli.Command)) {
+	for _, c := range sortedSubs(cmds) {
+		fn(c)
+		walkCmds(c.Commands(), fn)
+	}
+}
@@ -0,0 +1,229 @@
+package docgen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/gflag"
+)
+
+// SingleMarkdown 把应用的整个命令树渲染为单个 markdown 文档(含目录), 可直接嵌入 README 作为 CLI 参考。
+//
+//   - 命令标题级别随命令层级递增: 应用标题为 opts.HeadingLevel(默认 1), 顶层命令 +1, 依此类推
+//   - 共享选项只在定义它的命令处列出一次, 子孙命令仅注明继承自哪个命令
+//   - Help/Examples 中的帮助变量会被完全展开(渲染前会强制初始化命令)
+//
+// opts 中仅 HeadingLevel 与页脚设置(Footer/DisableFooter/Date)生效, nil 使用默认值。
+func SingleMarkdown(app *gcli.App, opts *Options) string {
+	var buf strings.Builder
+	level := opts.headingLevel()
+
+	buf.WriteString(mdHeading(level, app.Name))
+	if app.Desc != "" {
+		buf.WriteString(cleanLine(color.ClearTag(app.ReplacePairs(app.Desc))) + "\n\n")
+	}
+	if app.Version != "" {
+		buf.WriteString("**Version**: " + app.Version + "\n\n")
+	}
+
+	cmds := app.Commands()
+	if len(cmds) > 0 {
+		buf.WriteString(mdHeading(level+1, "Commands"))
+		writeMdTOC(&buf, cmds, 0)
+		buf.WriteString("\n")
+	}
+
+	sd := &singleDoc{buf: &buf, level: level}
+	walkCmds(cmds, sd.writeCmd)
+
+	writeFooter(&buf, opts)
+	return buf.String()
+}
+
+// SingleCmdMarkdown 把命令及其全部子孙命令渲染为单个 markdown 文档, 规则同 SingleMarkdown。
+//
+// 命令可以未添加到 app(如独立运行的命令), 渲染前会强制初始化以加载子命令并展开帮助变量。
+// 祖先命令的共享选项会在该命令处以 "Inherited Options" 列出一次。
+func SingleCmdMarkdown(c *gcli.Command, opts *Options) string {
+	var buf strings.Builder
+	c.Init()
+
+	sd := &singleDoc{buf: &buf, level: opts.headingLevel(), root: c}
+	sd.writeCmd(c)
+	if subs := c.Commands(); len(subs) > 0 {
+		buf.WriteString("**Commands**:\n\n")
+		writeMdTOC(&buf, subs, 0)
+		buf.WriteString("\n")
+		walkCmds(subs, sd.writeCmd)
+	}
+
+	writeFooter(&buf, opts)
+	return buf.String()
+}
+
+// singleDoc 单文档模式的命令渲染状态
+type singleDoc struct {
+	buf *strings.Builder
+	// level 应用(或 root 命令)标题的级别
+	level int
+	// root 文档的根命令, 为 nil 表示整个应用
+	root *gcli.Command
+}
+
+// cmdLevel 命令标题级别: 跟随相对文档根的命令层级
+func (sd *singleDoc) cmdLevel(c *gcli.Command) int {
+	if sd.root == nil {
+		return sd.level + len(c.PathNames())
+	}
+	return sd.level + len(c.PathNames()) - len(sd.root.PathNames())
+}
+
+// writeCmd 渲染一个命令小节。命令内部的分节使用粗体标签, 避免与子命令标题层级冲突。
+func (sd *singleDoc) writeCmd(c *gcli.Command) {
+	// 强制初始化: 加载子命令、执行 Config 并设置帮助变量, 保证 Help/Examples 可完全展开
+	c.Init()
+	buf := sd.buf
+
+	buf.WriteString(mdHeading(sd.cmdLevel(c), c.Path()))
+	if c.Desc != "" {
+		buf.WriteString(cleanLine(renderText(c, c.Desc)) + "\n\n")
+	}
+	if c.Deprecated != "" {
+		buf.WriteString("> **Deprecated**: " + cleanLine(c.Deprecated) + "\n\n")
+	}
+
+	buf.WriteString("```\n" + c.Path() + " [--options ...] [arguments ...]\n```\n\n")
+	if len(c.Aliases) > 0 {
+		buf.WriteString("**Aliases**: `" + c.Aliases.String() + "`\n\n")
+	}
+	if c.Help != "" {
+		buf.WriteString("**Synopsis**:\n\n" + strings.TrimSpace(renderText(c, c.Help)) + "\n\n")
+	}
+
+	// 本命令选项: 排除继承来的副本与自身定义的共享选项(后者单独列出)
+	var shared map[string]*gflag.CliOpt
+	if c.HasSharedOpts() {
+		shared = c.SharedOpts().Opts()
+	}
+	rows := optRowsOf(c, c.Opts(), func(opt *gflag.CliOpt) bool {
+		return opt.Category == gcli.InheritedOptsCategory || shared[opt.Name] != nil
+	})
+	writeMdOptTable(buf, "Options", rows)
+
+	if len(shared) > 0 {
+		writeMdOptTable(buf, "Shared Options", optRowsOf(c, shared, nil))
+		buf.WriteString("Shared options are also accepted by all subcommands.\n\n")
+	}
+	sd.writeInherited(c, shared)
+
+	if args := c.Args(); len(args) > 0 {
+		buf.WriteString("**Arguments**:\n\n")
+		buf.WriteString("Argument | Required | Description\n")
+		buf.WriteString("---------|----------|------------\n")
+		for _, arg := range args {
+			required := "No"
+			if arg.Required {
+				required = "Yes"
+			}
+			buf.WriteString(fmt.Sprintf("`%s` | %s | %s\n",
+				escapeTableCell(argName(arg)), required, escapeTableCell(renderText(c, arg.Desc))))
+		}
+		buf.WriteString("\n")
+	}
+
+	if c.Examples != "" {
+		buf.WriteString("**Examples**:\n\n```\n" + examplesText(c) + "\n```\n\n")
+	}
+}
+
+// writeInherited 说明命令继承的共享选项。祖先在文档内时仅链接到其小节(每个子树只列一次);
+// 祖先在文档外(SingleCmdMarkdown 的根命令之上)时, 在根命令处列出这些选项。
+func (sd *singleDoc) writeInherited(c *gcli.Command, shared map[string]*gflag.CliOpt) {
+	var links []string
+	outside := make(map[string]*gflag.CliOpt)
+	inDoc := sd.root == nil
+
+	var chain []*gcli.Command
+	for anc := c.Parent(); anc != nil; anc = anc.Parent() {
+		chain = append(chain, anc)
+	}
+	// 从根到叶, 与共享选项的合并顺序一致
+	for i := len(chain) - 1; i >= 0; i-- {
+		anc := chain[i]
+		if anc == sd.root {
+			inDoc = true
+		}
+		if !anc.HasSharedOpts() {
+			continue
+		}
+
+		if inDoc {
+			links = append(links, fmt.Sprintf("[%s](#%s)", anc.Path(), mdAnchor(anc.Path())))
+		} else if c == sd.root {
+			for name, opt := range anc.SharedOpts().Opts() {
+				outside[name] = opt // 更近的祖先优先
+			}
+		}
+	}
+
+	// 本地定义的同名选项优先, 不再列出
+	for name := range outside {
+		if opt := c.Opts()[name]; (opt != nil && opt.Category != gcli.InheritedOptsCategory) || shared[name] != nil {
+			delete(outside, name)
+		}
+	}
+	writeMdOptTable(sd.buf, "Inherited Options", optRowsOf(c, outside, nil))
+
+	if len(links) > 0 {
+		sd.buf.WriteString("Also accepts the shared options of: " + strings.Join(links, ", ") + "\n\n")
+	}
+}
+
+// writeMdOptTable 以粗体标签 + 表格写选项行, rows 为空时不输出。
+func writeMdOptTable(buf *strings.Builder, title string, rows []optRow) {
+	if len(rows) == 0 {
+		return
+	}
+
+	buf.WriteString("**" + title + "**:\n\n")
+	buf.WriteString("Option | Type | Default | Required | Description\n")
+	buf.WriteString("-------|------|---------|----------|------------\n")
+	for _, r := range rows {
+		buf.WriteString(fmt.Sprintf("`%s` | %s | %s | %s | %s\n",
+			escapeTableCell(r.Name), escapeTableCell(r.Type), escapeTableCell(r.Default),
+			r.Required, escapeTableCell(r.Desc)))
+	}
+	buf.WriteString("\n")
+}
+
+// writeMdTOC 写命令树目录(嵌套列表, 链接到各命令小节的锚点)
+func writeMdTOC(buf *strings.Builder, cmds map[string]*gcli.Command, depth int) {
+	for _, c := range sortedSubs(cmds) {
+		c.Init()
+		buf.WriteString(fmt.Sprintf("%s- [%s](#%s) - %s\n",
+			strings.Repeat("  ", depth), c.Path(), mdAnchor(c.Path()), cmdDesc(c)))
+		writeMdTOC(buf, c.Commands(), depth+1)
+	}
+}
+
+// mdHeading markdown 标题, 级别最大为 6
+func mdHeading(level int, text string) string {
+	return strings.Repeat("#", min(level, 6)) + " " + text + "\n\n"
+}
+
+// mdAnchor 按 GitHub 规则计算标题锚点: 转小写, 去除标点, 空格转为 `-`。eg: `remote add` -> `remote-add`
+func mdAnchor(title string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == ' ':
+			sb.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
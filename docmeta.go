@@ -0,0 +1,47 @@
+package gcli
+
+// DocItem a named entry in the generated documentation. eg: an env var, exit code or file
+type DocItem struct {
+	// Name of the item. eg: "APP_DEBUG", "2", "~/.myapp.yaml"
+	Name string
+	// Desc the item description
+	Desc string
+}
+
+// DocMeta extra metadata for the generated man pages and markdown docs. see package docgen
+//
+// Can be set on the App and on each Command, the command values take precedence.
+// Option env bindings(eg: default value "${APP_TOKEN}") and sibling commands are
+// added to EnvVars and SeeAlso automatically on generate docs.
+//
+// Usage:
+//
+//	app.DocMeta = gcli.DocMeta{
+//		ExitCodes: []gcli.DocItem{{"0", "success"}, {"1", "runtime error"}},
+//		Files:     []gcli.DocItem{{"~/.myapp.yaml", "the user config file"}},
+//		Authors:   []string{"inhere <in.798@qq.com>"},
+//		BugURL:    "https://github.com/inhere/myapp/issues",
+//	}
+type DocMeta struct {
+	// EnvVars the environment variables used
+	EnvVars []DocItem
+	// ExitCodes the exit status codes. Name is the code, eg: "0"
+	ExitCodes []DocItem
+	// Files the files used. eg: config files
+	Files []DocItem
+	// SeeAlso related man pages or links. eg: "git(1)"
+	SeeAlso []string
+	// Authors eg: "inhere <in.798@qq.com>"
+	Authors []string
+	// BugURL the URL for reporting bugs
+	BugURL string
+
+	// --- man page header(.TH) ---
+
+	// Date of the man page. default is the $SOURCE_DATE_EPOCH or current date. eg: "Jan 2025"
+	Date string
+	// Source of the man page. default is "{app name} {version}"
+	Source string
+	// Manual title of the man page. eg: "User Commands"
+	Manual string
+}
@@ -0,0 +1,129 @@
+package gcli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gookit/gcli/v3/gi18n"
+	"github.com/gookit/gcli/v3/internal/helper"
+)
+
+// ErrNoInteractive returned by Command.Confirm on the user cannot be prompted: the stdin is
+// not a terminal(eg: in CI) or the interactive is disabled. check it by errors.Is
+var ErrNoInteractive = errors.New("non-interactive mode")
+
+// EnableDryRun bind the option --dry-run for the command, should be called in the Config func.
+// shared is true will bind it as a shared option, all subcommands accept it too. see SharedOpts
+//
+// Usage:
+//
+//	Config: func(c *gcli.Command) {
+//		c.EnableDryRun()
+//	},
+//	Func: func(c *gcli.Command, args []string) error {
+//		for _, file := range files {
+//			if c.WouldDo("delete the file %s", file) {
+//				continue
+//			}
+//			// do delete ...
+//		}
+//	}
+func (c *Command) EnableDryRun(shared ...bool) *Command {
+	if len(shared) > 0 && shared[0] {
+		c.SharedOpts().BoolOpt(&c.dryRun, "dry-run", "", false, gi18n.T("opt.dryRun"))
+	} else {
+		c.BoolOpt(&c.dryRun, "dry-run", "", false, gi18n.T("opt.dryRun"))
+	}
+	return c
+}
+
+// EnableYes bind the option --yes, -y for the command, for automatic yes to the Confirm prompts.
+// should be called in the Config func. shared is true will bind it as a shared option.
+func (c *Command) EnableYes(shared ...bool) *Command {
+	if len(shared) > 0 && shared[0] {
+		c.SharedOpts().BoolOpt(&c.assumeYes, "yes", "y", false, gi18n.T("opt.yes"))
+	} else {
+		c.BoolOpt(&c.assumeYes, "yes", "y", false, gi18n.T("opt.yes"))
+	}
+	return c
+}
+
+// DryRun check the command is in dry-run mode. by the --dry-run of the command or its parents.
+func (c *Command) DryRun() bool {
+	for cur := c; cur != nil; cur = cur.parent {
+		if cur.dryRun {
+			return true
+		}
+	}
+	return false
+}
+
+// assumedYes check the --yes is given for the command or its parents.
+func (c *Command) assumedYes() bool {
+	for cur := c; cur != nil; cur = cur.parent {
+		if cur.assumeYes {
+			return true
+		}
+	}
+	return false
+}
+
+// WouldDo print the plan message like "[dry-run] would delete the file a.txt" and returns true
+// in dry-run mode. otherwise, returns false and nothing is printed.
+//
+// Usage:
+//
+//	if c.WouldDo("restart the service %s", name) {
+//		return nil
+//	}
+func (c *Command) WouldDo(format string, v ...any) bool {
+	if !c.DryRun() {
+		return false
+	}
+
+	c.ownerOpts().println(gi18n.T("tip.wouldDo", fmt.Sprintf(format, v...)))
+	return true
+}
+
+// Confirm ask the user to confirm the message, returns true on the answer is yes(y, yes).
+//
+//   - returns false without prompt in dry-run mode, even the --yes is given. nothing should be
+//     changed in dry-run, so check the DryRun() first and print the plan by WouldDo.
+//   - returns true without prompt on the --yes is given(see EnableYes).
+//   - returns false and ErrNoInteractive on cannot prompt: GlobalOpts.NoInteractive is true
+//     (env NO_INTERACTIVE=1) or the stdin is not a terminal. so it fails safely in CI.
+//
+// Usage:
+//
+//	if !c.DryRun() {
+//		if ok, err := c.Confirm("delete all the files?"); !ok {
+//			return err // err is nil on the user answered no
+//		}
+//	}
+func (c *Command) Confirm(msg string) (bool, error) {
+	if c.DryRun() {
+		return false, nil
+	}
+	if c.assumedYes() {
+		return true, nil
+	}
+
+	opts := c.ownerOpts()
+	if gOpts.NoInteractive || !inIsTerminal(opts.In()) {
+		errMsg := gi18n.T("err.noInteractive", msg)
+		if c.HasOption("yes") {
+			errMsg += ", " + gi18n.T("tip.useYes")
+		}
+		return false, fmt.Errorf("%w: %s", ErrNoInteractive, errMsg)
+	}
+
+	opts.printf("%s [y/N]: ", msg)
+	ans, err := helper.ReadLine(opts.In())
+	if err != nil {
+		return false, err
+	}
+
+	ans = strings.ToLower(ans)
+	return ans == "y" || ans == "yes", nil
+}
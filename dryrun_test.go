@@ -0,0 +1,123 @@
+package gcli_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/gclitest"
+	"github.com/gookit/goutil/x/assert"
+)
+
+func newDryRunApp(shared bool) *gcli.App {
+	app := gcli.NewApp(gcli.NotExitOnEnd())
+	clean := func(c *gcli.Command, _ []string) error {
+		if !c.DryRun() {
+			ok, err := c.Confirm("delete all the files?")
+			if !ok {
+				return err
+			}
+		}
+
+		for _, file := range []string{"a.txt", "b.txt"} {
+			if c.WouldDo("delete the file %s", file) {
+				continue
+			}
+			fmt.Fprintln(c.Out(), "deleted:", file)
+		}
+		return nil
+	}
+
+	app.Add(&gcli.Command{
+		Name: "clean",
+		Config: func(c *gcli.Command) {
+			c.EnableDryRun(shared).EnableYes(shared)
+		},
+		Func: clean,
+		Subs: []*gcli.Command{
+			{Name: "cache", Func: clean},
+		},
+	})
+	return app
+}
+
+// newDropApp the command not check DryRun() before Confirm
+func newDropApp() *gcli.App {
+	app := gcli.NewApp(gcli.NotExitOnEnd())
+	app.Add(&gcli.Command{
+		Name: "drop",
+		Config: func(c *gcli.Command) {
+			c.EnableDryRun().EnableYes()
+		},
+		Func: func(c *gcli.Command, _ []string) error {
+			if ok, _ := c.Confirm("drop the database?"); ok {
+				fmt.Fprintln(c.Out(), "dropped")
+			}
+			return nil
+		},
+	})
+	return app
+}
+
+func TestCommand_EnableDryRun(t *testing.T) {
+	res := gclitest.Run(newDryRunApp(false), "clean --dry-run")
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "[dry-run] would delete the file a.txt\n[dry-run] would delete the file b.txt\n", res.Stdout)
+
+	res = gclitest.Run(newDryRunApp(false), "clean -h")
+	assert.StrContains(t, res.Stdout, "--dry-run")
+	assert.StrContains(t, res.Stdout, "-y, --yes")
+
+	// not shared
+	res = gclitest.Run(newDryRunApp(false), "clean cache --dry-run")
+	assert.Err(t, res.Err)
+
+	// shared option: the subcommand accepts it
+	res = gclitest.Run(newDryRunApp(true), "clean cache --dry-run")
+	assert.NoErr(t, res.Err)
+	assert.StrContains(t, res.Stdout, "[dry-run] would delete the file a.txt")
+	res = gclitest.Run(newDryRunApp(true), "clean cache -y")
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "deleted: a.txt\ndeleted: b.txt\n", res.Stdout)
+}
+
+func TestCommand_Confirm(t *testing.T) {
+	// --yes: no prompt
+	res := gclitest.Run(newDryRunApp(false), "clean --yes")
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "deleted: a.txt\ndeleted: b.txt\n", res.Stdout)
+
+	// stdin is not a terminal: fail safely
+	res = gclitest.Run(newDryRunApp(false), "clean", gclitest.WithStdin("y\n"))
+	assert.Err(t, res.Err)
+	assert.True(t, errors.Is(res.Err, gcli.ErrNoInteractive))
+	assert.Eq(t, `non-interactive mode: cannot confirm "delete all the files?", use --yes to confirm`, res.Err.Error())
+	assert.NotEq(t, 0, res.Code)
+	assert.NotContains(t, res.Stdout, "deleted:")
+
+	// answer on the terminal
+	res = gclitest.Run(newDryRunApp(false), "clean", gclitest.WithTTY(true), gclitest.WithStdin("y\n"))
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "delete all the files? [y/N]: deleted: a.txt\ndeleted: b.txt\n", res.Stdout)
+
+	res = gclitest.Run(newDryRunApp(false), "clean", gclitest.WithTTY(true), gclitest.WithStdin("n\n"))
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, 0, res.Code)
+	assert.NotContains(t, res.Stdout, "deleted:")
+
+	// dry-run: never confirmed, even with --yes
+	for _, line := range []string{"drop --dry-run", "drop --dry-run --yes"} {
+		res = gclitest.Run(newDropApp(), line, gclitest.WithTTY(true), gclitest.WithStdin("y\n"))
+		assert.NoErr(t, res.Err)
+		assert.Eq(t, "", res.Stdout)
+	}
+	res = gclitest.Run(newDropApp(), "drop --yes")
+	assert.Eq(t, "dropped\n", res.Stdout)
+
+	// disabled the interactive by GlobalOpts
+	defer gcli.ResetGOpts()
+	gcli.GOpts().NoInteractive = true
+	res = gclitest.Run(newDryRunApp(false), "clean", gclitest.WithTTY(true), gclitest.WithStdin("y\n"))
+	assert.True(t, errors.Is(res.Err, gcli.ErrNoInteractive))
+}
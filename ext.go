@@ -2,7 +2,9 @@ package gcli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/gookit/gcli/v3/gevent"
@@ -93,6 +95,15 @@ func (e *runErr) Error() string {
 	return fmt.Sprintf("%v (exit code %d)", e.err, e.code)
 }
 
+// errExitCode get exit code from the error, default is ERR.
+func errExitCode(err error) int {
+	var ec errorx.ErrorCoder
+	if errors.As(err, &ec) {
+		return ec.Code()
+	}
+	return ERR.ToInt()
+}
+
 // HookFunc definition.
 //
 // Returns:
@@ -104,64 +115,166 @@ type HookFunc func(ctx *HookCtx) (stop bool)
  * simple events manage
  *************************************************************/
 
+// hook listener priorities. the higher priority listener will be called first.
+const (
+	HookPriorityMin    = -300
+	HookPriorityLow    = -200
+	HookPriorityNormal = 0
+	HookPriorityHigh   = 200
+	HookPriorityMax    = 300
+)
+
+// hookListener a registered hook handler with id and priority.
+type hookListener struct {
+	id       int
+	priority int
+	handler  HookFunc
+}
+
 // Hooks struct. hookManager
+//
+// An event can have multiple listeners, they are called by priority(high first),
+// and then by registration order. A listener returns True will stop calling the rest.
 type Hooks struct {
+	// seq for generate listener id
+	seq int
 	// pfxHooks can set prefix match hooks func on running.
 	// eg: app.run.* => app.run.init, app.run.after
-	pfxHooks map[string]HookFunc
+	pfxHooks map[string][]*hookListener
 	// Hooks can set some hooks func on running.
-	hooks map[string]HookFunc
+	hooks map[string][]*hookListener
 }
 
-// On register event hook by name
-func (h *Hooks) On(name string, handler HookFunc) {
+// On register event hook by name, returns the listener id. use it for remove by Off().
+//
+// priority is optional, default is HookPriorityNormal. the higher priority will be called first.
+//
+// Usage:
+//
+//	h.On(gevent.OnCmdRunError, fn)
+//	id := h.On("app.run.*", fn, gcli.HookPriorityHigh) // prefix match
+//	h.On("*", fn) // match all events
+func (h *Hooks) On(name string, handler HookFunc, priority ...int) int {
 	if handler == nil {
 		panicf("event %q handler is nil", name)
 	}
 
+	h.seq++
+	lis := &hookListener{id: h.seq, handler: handler}
+	if len(priority) > 0 {
+		lis.priority = priority[0]
+	}
+
 	if strings.HasSuffix(name, ".*") {
 		if h.pfxHooks == nil {
-			h.pfxHooks = make(map[string]HookFunc)
+			h.pfxHooks = make(map[string][]*hookListener)
 		}
 
-		h.pfxHooks[name[:len(name)-2]] = handler
-		return
+		pfx := name[:len(name)-2]
+		h.pfxHooks[pfx] = addListener(h.pfxHooks[pfx], lis)
+		return lis.id
 	}
 
 	if h.hooks == nil {
-		h.hooks = make(map[string]HookFunc)
+		h.hooks = make(map[string][]*hookListener)
+	}
+	h.hooks[name] = addListener(h.hooks[name], lis)
+	return lis.id
+}
+
+// add listener to list, keep sorted by priority(high first), then by registration order.
+func addListener(ls []*hookListener, lis *hookListener) []*hookListener {
+	idx := len(ls)
+	for i, l := range ls {
+		if lis.priority > l.priority {
+			idx = i
+			break
+		}
+	}
+	return slices.Insert(ls, idx, lis)
+}
+
+// Off remove the hook listeners of the event by ids. will remove all listeners on ids is empty.
+//
+// name can be a prefix hook name. eg: "app.run.*"
+func (h *Hooks) Off(name string, ids ...int) {
+	if h == nil {
+		return
+	}
+
+	hooks := h.hooks
+	if strings.HasSuffix(name, ".*") {
+		hooks, name = h.pfxHooks, name[:len(name)-2]
+	}
+	if len(hooks[name]) == 0 {
+		return
+	}
+
+	if len(ids) == 0 {
+		delete(hooks, name)
+		return
+	}
+
+	ls := slices.DeleteFunc(hooks[name], func(l *hookListener) bool {
+		return slices.Contains(ids, l.id)
+	})
+	if len(ls) == 0 {
+		delete(hooks, name)
+	} else {
+		hooks[name] = ls
 	}
-	h.hooks[name] = handler
 }
 
 // AddHook register on not exists hook.
 func (h *Hooks) AddHook(name string, handler HookFunc) {
-	if _, ok := h.hooks[name]; !ok {
+	if !h.HasHook(name) {
 		h.On(name, handler)
 	}
 }
 
 // Fire event by name, allow with event data.
 // returns True for stop continue run.
+//
+// Calling order:
+//
+//   - the listeners of the event name
+//   - the prefix match listeners, longer prefix first. eg: "app.run.*" before "app.*"
+//   - the "*" listeners
 func (h *Hooks) Fire(event string, ctx *HookCtx) (stop bool) {
-	if fn, ok := h.hooks[event]; ok {
-		if fn(ctx) {
-			return true
-		}
+	if fireListeners(h.hooks[event], ctx) {
+		return true
 	}
 
-	// check prefix match hooks
-	for name, fn := range h.pfxHooks {
-		if strings.HasPrefix(event, name) {
-			if fn(ctx) {
+	// check prefix match hooks. sort for a deterministic order
+	if len(h.pfxHooks) > 0 {
+		pfxNames := make([]string, 0, len(h.pfxHooks))
+		for name := range h.pfxHooks {
+			if strings.HasPrefix(event, name) {
+				pfxNames = append(pfxNames, name)
+			}
+		}
+
+		slices.SortFunc(pfxNames, func(a, b string) int {
+			if len(a) != len(b) {
+				return len(b) - len(a)
+			}
+			return strings.Compare(a, b)
+		})
+		for _, name := range pfxNames {
+			if fireListeners(h.pfxHooks[name], ctx) {
 				return true
 			}
 		}
 	}
 
 	// check * hook
-	if fn, ok := h.hooks["*"]; ok {
-		if fn(ctx) {
+	return fireListeners(h.hooks["*"], ctx)
+}
+
+// call listeners in order, returns True on a listener stop it.
+func fireListeners(ls []*hookListener, ctx *HookCtx) bool {
+	for _, l := range ls {
+		if l.handler(ctx) {
 			return true
 		}
 	}
@@ -170,8 +283,7 @@ func (h *Hooks) Fire(event string, ctx *HookCtx) (stop bool) {
 
 // HasHook registered check.
 func (h *Hooks) HasHook(event string) bool {
-	_, ok := h.hooks[event]
-	return ok
+	return len(h.hooks[event]) > 0
 }
 
 // ResetHooks clear all hooks
@@ -192,7 +304,7 @@ type HookCtx struct {
 	Cmd *Command
 
 	stop bool  // stop to continue handle.
-	err  error // set handle error. TODO
+	err  error // the event error. from the data "err" on fire the error event
 	name string
 }
 
@@ -212,14 +324,39 @@ func newHookCtx(name string, c *Command, data map[string]any) *HookCtx {
 	if c != nil {
 		hc.App = c.app
 	}
+	// populate the event error. eg: on gevent.OnAppRunError, gevent.OnCmdRunError
+	if err, ok := data[gevent.KeyErr].(error); ok {
+		hc.err = err
+	}
 	return hc
 }
 
-// Err of event
+// Err of event. it is not nil on error events, eg: gevent.OnAppRunError, gevent.OnCmdRunError
 func (hc *HookCtx) Err() error {
 	return hc.err
 }
 
+// Args of the event. eg: on gevent.OnCmdRunBefore, gevent.OnCmdOptParsed, gevent.OnCmdNotFound
+func (hc *HookCtx) Args() []string {
+	if args, ok := hc.Data[gevent.KeyArgs].([]string); ok {
+		return args
+	}
+	return nil
+}
+
+// CmdPath of the event command. eg: "git remote add"
+//
+// will use the data "cmd" on ctx.Cmd is nil, returns empty on the event is not related to a command.
+func (hc *HookCtx) CmdPath() string {
+	if hc.Cmd != nil {
+		return hc.Cmd.Path()
+	}
+	return hc.Str(gevent.KeyCmd)
+}
+
+// ExitCode on the gevent.OnAppExit event
+func (hc *HookCtx) ExitCode() int { return hc.Int(gevent.KeyCode) }
+
 // Name of event
 func (hc *HookCtx) Name() string { return hc.name }
 
@@ -242,6 +379,9 @@ func (hc *HookCtx) WithErr(err error) *HookCtx {
 func (hc *HookCtx) WithData(data map[string]any) *HookCtx {
 	if data != nil {
 		hc.Data = data
+		if err, ok := data[gevent.KeyErr].(error); ok {
+			hc.err = err
+		}
 	}
 	return hc
 }
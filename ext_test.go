@@ -1,9 +1,11 @@
 package gcli_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/gevent"
 	"github.com/gookit/goutil/byteutil"
 	"github.com/gookit/goutil/x/assert"
 )
@@ -63,3 +65,109 @@ func TestHooks_Fire(t *testing.T) {
 	is.StrContains(s, "fire the app.test.* hook")
 	is.StrContains(s, "fire the * hook")
 }
+
+func TestHooks_multiListeners(t *testing.T) {
+	is := assert.New(t)
+	buf := byteutil.NewBuffer()
+	hooks := gcli.Hooks{}
+
+	newFn := func(s string, stop bool) gcli.HookFunc {
+		return func(ctx *gcli.HookCtx) bool {
+			buf.WriteString(s + ";")
+			return stop
+		}
+	}
+
+	hooks.On("test", newFn("a", false))
+	idB := hooks.On("test", newFn("b", false))
+	hooks.On("test", newFn("c", false), gcli.HookPriorityHigh)
+	hooks.On("test", newFn("d", false), gcli.HookPriorityLow)
+
+	// priority first, then by registration order
+	hooks.Fire("test", nil)
+	is.Eq("c;a;b;d;", buf.ResetGet())
+
+	t.Run("off by id", func(t *testing.T) {
+		hooks.Off("test", idB)
+		hooks.Fire("test", nil)
+		is.Eq("c;a;d;", buf.ResetGet())
+	})
+
+	t.Run("stop the rest", func(t *testing.T) {
+		hooks.On("test", newFn("stop", true), gcli.HookPriorityMax)
+		is.True(hooks.Fire("test", nil))
+		is.Eq("stop;", buf.ResetGet())
+	})
+
+	t.Run("off all", func(t *testing.T) {
+		hooks.Off("test")
+		is.False(hooks.HasHook("test"))
+		is.False(hooks.Fire("test", nil))
+		is.Empty(buf.ResetGet())
+	})
+
+	t.Run("deterministic prefix order", func(t *testing.T) {
+		hooks.On("*", newFn("*", false))
+		hooks.On("app.*", newFn("app.*", false))
+		hooks.On("app.run.*", newFn("app.run.*", false))
+		hooks.On("app.run.*", newFn("app.run.*2", false))
+		hooks.On("app.run.init", newFn("init", false))
+
+		for i := 0; i < 5; i++ {
+			hooks.Fire("app.run.init", nil)
+			is.Eq("init;app.run.*;app.run.*2;app.*;*;", buf.ResetGet())
+		}
+
+		hooks.Off("app.run.*")
+		hooks.Fire("app.run.init", nil)
+		is.Eq("init;app.*;*;", buf.ResetGet())
+	})
+}
+
+func TestHookCtx_typedPayload(t *testing.T) {
+	is := assert.New(t)
+	app := gcli.NewApp(gcli.NotExitOnEnd())
+
+	sub := gcli.NewCommand("add", "desc").WithFunc(func(c *gcli.Command, args []string) error {
+		return errors.New("add failed")
+	})
+	sub.AddArg("name", "desc")
+	app.Add(gcli.NewCommand("remote", "desc", func(c *gcli.Command) {
+		c.AddSubs(sub)
+	}))
+
+	var runArgs []string
+	var cmdErr, appErr error
+	var errPath, appErrPath string
+	var exitCode int
+	app.On(gevent.OnCmdOptParsed, func(ctx *gcli.HookCtx) bool {
+		runArgs = ctx.Args()
+		return false
+	})
+	app.On(gevent.OnCmdRunError, func(ctx *gcli.HookCtx) bool {
+		cmdErr, errPath = ctx.Err(), ctx.CmdPath()
+		return true // skip default error handler
+	})
+	app.On(gevent.OnAppRunError, func(ctx *gcli.HookCtx) bool {
+		appErr, appErrPath = ctx.Err(), ctx.CmdPath()
+		return true
+	})
+	app.On(gevent.OnAppExit, func(ctx *gcli.HookCtx) bool {
+		exitCode = ctx.ExitCode()
+		return false
+	})
+
+	is.Eq(2, app.Run([]string{"remote", "add", "origin"}))
+	is.Eq([]string{"origin"}, runArgs)
+	is.ErrMsg(cmdErr, "add failed")
+	is.Eq("remote add", errPath)
+	is.ErrMsg(appErr, "add failed")
+	is.Eq("remote", appErrPath)
+	is.Eq(2, exitCode)
+
+	// no payload
+	ctx := &gcli.HookCtx{}
+	is.Nil(ctx.Args())
+	is.Nil(ctx.Err())
+	is.Eq("", ctx.CmdPath())
+}
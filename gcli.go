@@ -12,11 +12,15 @@ package gcli
 import (
 	"flag"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/goutil/cflag"
 	"github.com/gookit/goutil/envutil"
 )
@@ -75,9 +79,10 @@ var (
 	// CommitID the gcli last commit ID
 	commitID = "z20210214"
 
-	// global vars
+	// gOpts the process-level default options. each App can override them, see AppOptions
 	gOpts = newGlobalOpts()
-	gCtx  = NewCtx().InitCtx()
+	// gCtx the process-level context. App and standalone command create their own Context.
+	gCtx = NewCtx().InitCtx()
 )
 
 // init
@@ -86,9 +91,15 @@ func init() {
 	if verb := os.Getenv(VerbEnvName); verb != "" {
 		_ = gOpts.Verbose.Set(verb)
 	}
+	// set the default slog logger from ENV var.
+	if format := os.Getenv(LogFormatEnvName); format != "" && checkLogFormat(format) == nil {
+		gOpts.logger = slog.New(NewLogHandler(os.Stderr, format, nil))
+	}
 }
 
-// GCtx get the global ctx
+// GCtx get the process-level ctx.
+//
+// NOTE: it is no longer shared with the apps, each App has its own Context. see App.Ctx
 func GCtx() *Context {
 	return gCtx
 }
@@ -153,11 +164,12 @@ func NewArgument(name, desc string, requiredAndArrayed ...bool) *Argument {
  * global options
  *************************************************************************/
 
-// GlobalOpts process-level config options. shared across all App instances via
-// the package singleton gOpts; set by gcli.SetVerbose / SetStrictMode / SetEnhanceShort.
+// GlobalOpts process-level default config options, held by the package singleton gOpts;
+// set by gcli.SetVerbose / SetStrictMode / SetEnhanceShort.
 //
-// NOTE: per-app parse/run state (help/version/completion) lives in AppOptions, so
-// multiple App instances in one process don't share it. see App.AppOpts().
+// The verbose, strict mode and enhanceShort can be overridden per App(see App.SetVerbose),
+// an App inherits the process defaults for the values it does not set. The per-app parse/run
+// state (help/version/completion) lives in AppOptions. see App.AppOpts().
 type GlobalOpts struct {
 	// Disable auto binding global options
 	Disable bool
@@ -182,6 +194,9 @@ type GlobalOpts struct {
 	// enhanceShort global POSIX short-option enhance level, applied to every command
 	// that does not set its own Config.EnhanceShort. see EnhanceShortNone/Merge/Attach
 	enhanceShort uint8
+	// logger the slog logger for the diagnostics, nil for the colored text output.
+	// can set by env: GCLI_LOG_FORMAT=json. see SetLogger, LogFormatEnvName
+	logger *slog.Logger
 }
 
 // SetVerbose value
@@ -216,9 +231,22 @@ func newGlobalOpts() *GlobalOpts {
 	return opts
 }
 
-// AppOptions per-app(or standalone command) parse & run state. Each App owns its
+// AppOptions per-app(or standalone command) parse & run state and config. Each App owns its
 // own instance, so concurrent App instances in one process don't share these.
 type AppOptions struct {
+	// per-app config, overrides the process-level defaults in GlobalOpts.
+	// nil means inherit the process default. see SetVerbose, SetStrictMode, SetEnhanceShort, SetNoColor
+	verbose      *VerbLevel
+	strictMode   *bool
+	enhanceShort *uint8
+	noColor      *bool
+	logger       *slog.Logger
+
+	// in, out, errOut the input and outputs of the app. nil for the std streams. see SetOutput
+	in     io.Reader
+	out    io.Writer
+	errOut io.Writer
+
 	// ShowHelp show help information, then exit.
 	ShowHelp bool
 	// ShowVersion show version information, then exit.
@@ -229,15 +257,98 @@ type AppOptions struct {
 	// genCompletion direct generate shell auto completion scripts, then exit.
 	// eg "./cli --gen-completion bash|zsh|pwsh"
 	genCompletion string
+	// noPager disable the pager. by --no-pager, see App.EnablePager
+	noPager bool
+	// logFormat, logLevel by --log-format, --log-level. see App.EnableLogOpts
+	logFormat string
+	logLevel  string
+
+	// warned the printed warning keys, make sure each warning is printed only once per app.
+	warnMu sync.Mutex
+	warned map[string]bool
 }
 
 // newAppOptions create a new per-app options instance.
 func newAppOptions() *AppOptions { return &AppOptions{} }
 
+// Verbose get the verbose level. returns the process default if not set.
+func (o *AppOptions) Verbose() VerbLevel {
+	if o.verbose != nil {
+		return *o.verbose
+	}
+	return gOpts.Verbose
+}
+
+// SetVerbose level, only for the app.
+func (o *AppOptions) SetVerbose(verbose VerbLevel) { o.verbose = &verbose }
+
+// IsGteVerbose check the verbose level is greater than or equal to verb.
+func (o *AppOptions) IsGteVerbose(verb VerbLevel) bool { return o.Verbose() >= verb }
+
+// StrictMode get is strict mode. returns the process default if not set.
+func (o *AppOptions) StrictMode() bool {
+	if o.strictMode != nil {
+		return *o.strictMode
+	}
+	return gOpts.strictMode
+}
+
+// SetStrictMode for parse flags, only for the app.
+func (o *AppOptions) SetStrictMode(strict bool) { o.strictMode = &strict }
+
+// EnhanceShort get the POSIX short-option enhance level. returns the process default if not set.
+func (o *AppOptions) EnhanceShort() uint8 {
+	if o.enhanceShort != nil {
+		return *o.enhanceShort
+	}
+	return gOpts.enhanceShort
+}
+
+// SetEnhanceShort level, only for the app. see EnhanceShortNone/Merge/Attach
+func (o *AppOptions) SetEnhanceShort(level uint8) { o.enhanceShort = &level }
+
+// NoColor check the color output is disabled. returns the process default(GlobalOpts.NoColor) if not set.
+func (o *AppOptions) NoColor() bool {
+	if o.noColor != nil {
+		return *o.noColor
+	}
+	return gOpts.NoColor
+}
+
+// SetNoColor disable the color output, only for the app.
+func (o *AppOptions) SetNoColor(noColor bool) { o.noColor = &noColor }
+
+// markWarned mark the warning key is printed. returns false if it has been printed.
+func (o *AppOptions) markWarned(key string) bool {
+	o.warnMu.Lock()
+	defer o.warnMu.Unlock()
+
+	if o.warned[key] {
+		return false
+	}
+	if o.warned == nil {
+		o.warned = make(map[string]bool)
+	}
+	o.warned[key] = true
+	return true
+}
+
+// ResetWarnings clear the printed warning keys, the warnings(eg: deprecated) will be printed again.
+func (o *AppOptions) ResetWarnings() {
+	o.warnMu.Lock()
+	o.warned = nil
+	o.warnMu.Unlock()
+}
+
+// InheritDefaults clear the per-app config, inherit all values from the process defaults again.
+func (o *AppOptions) InheritDefaults() {
+	o.verbose, o.strictMode, o.enhanceShort, o.noColor, o.logger = nil, nil, nil, nil, nil
+}
+
 // bindingOpts binds the per-app --help/-h (and --version/-V unless globally
 // disabled) onto the given parser. g provides the process-level Disable toggle.
 func (o *AppOptions) bindingOpts(fs *gflag.Parser, g *GlobalOpts) {
-	fs.BoolOpt(&o.ShowHelp, "help", "h", false, "Display the help information")
+	fs.BoolOpt(&o.ShowHelp, "help", "h", false, gi18n.T("opt.help"))
 	fs.AfterParse = func(_ *gflag.Parser) error {
 		// return ErrHelp on ShowHelp=true
 		if o.ShowHelp {
@@ -255,12 +366,12 @@ func (o *AppOptions) bindingOpts(fs *gflag.Parser, g *GlobalOpts) {
 	// 日志级别请通过环境变量 GCLI_VERBOSE 控制(见 VerbEnvName)，或调用 gcli.SetVerbose()。
 	// fs.BoolOpt(&g.NoColor, "no-color", "nc", g.NoColor, "Disable color when outputting message")
 	// fs.BoolOpt(&g.NoProgress, "no-progress", "np", g.NoProgress, "Disable display progress message")
-	fs.BoolOpt(&o.ShowVersion, "version", "V", false, "Display app version information")
+	fs.BoolOpt(&o.ShowVersion, "version", "V", false, gi18n.T("opt.version"))
 	// fs.BoolOpt(&g.NoInteractive, "no-interactive", "ni", g.NoInteractive, "Disable interactive confirmation operation")
 	// fs.BoolOpt(&g.inShell, "ishell", "", false, "Run in an interactive shell environment(`TODO`)")
 }
 
-// GOpts get the global options
+// GOpts get the process-level default options
 func GOpts() *GlobalOpts {
 	return gOpts
 }
@@ -285,10 +396,10 @@ func Version() string {
 // CommitID of the gcli
 func CommitID() string { return commitID }
 
-// Verbose returns Verbose level
+// Verbose returns the process default Verbose level
 func Verbose() VerbLevel { return gOpts.Verbose }
 
-// SetVerbose level by name or level
+// SetVerbose process default level by name or level. the apps without own level will use it.
 func SetVerbose[T VerbLevel | string](verbose T) {
 	if name, ok := any(verbose).(string); ok {
 		gOpts.SetVerbose(VerbLevelFrom(name))
@@ -306,20 +417,23 @@ func SetQuietMode() { SetVerbose(VerbQuiet) }
 // ResetVerbose level
 func ResetVerbose() { SetVerbose(defaultVerb) }
 
-// StrictMode get is strict mode
+// StrictMode get the process default is strict mode. it is not resolved through an app,
+// use App.AppOpts().StrictMode() for the value of an app.
 func StrictMode() bool { return gOpts.strictMode }
 
-// SetStrictMode for parse flags
+// SetStrictMode process default for parse flags. see App.SetStrictMode
 func SetStrictMode(strict bool) { gOpts.SetStrictMode(strict) }
 
-// EnhanceShort get the global POSIX short-option enhance level.
+// EnhanceShort get the process default POSIX short-option enhance level. it is not resolved
+// through an app, use App.AppOpts().EnhanceShort() for the level of an app.
 func EnhanceShort() uint8 { return gOpts.enhanceShort }
 
-// SetEnhanceShort set the global POSIX short-option enhance level for all commands.
+// SetEnhanceShort set the process default POSIX short-option enhance level for all commands.
 //
 // level: EnhanceShortNone(0) / EnhanceShortMerge(1) / EnhanceShortAttach(2).
 //
-// NOTE: a command's own Config.EnhanceShort (if set non-zero) takes priority over this.
+// NOTE: a command's own Config.EnhanceShort (if set non-zero) takes priority over this,
+// then the app's level set by App.SetEnhanceShort.
 func SetEnhanceShort(level uint8) { gOpts.SetEnhanceShort(level) }
 
 // IsGteVerbose get is strict mode
@@ -0,0 +1,315 @@
+// Package gclitest provide helpers for end-to-end testing the gcli applications.
+//
+// Run the app with an input command line, and get the captured stdout, stderr, exit code
+// and the returned error. Stdin, env vars and a fake TTY can be set for a run, and the
+// output can be compared with golden files(updated by the `-update` flag).
+//
+// Usage:
+//
+//	func TestServe(t *testing.T) {
+//		t.Parallel()
+//		res := gclitest.Run(newApp(), "serve --port 8080")
+//		assert.NoErr(t, res.Err)
+//		assert.Eq(t, 0, res.Code)
+//		res.AssertGolden(t, "serve")
+//	}
+//
+// The outputs are captured by the app writers(see App.SetOutput, App.SetInput), so the
+// commands should print to c.Out(), c.ErrOut() and read from c.In(). The process streams
+// are not changed, so the runs can be parallel.
+//
+// NOTE: the env vars, the fake TTY and the std streams are process-level. the Run with
+// WithEnv, WithEnvs, WithTTY or WithStdio holds a package lock while running, other runs
+// are waiting for it. Create a new App for each Run, the parsed state of an App is not reset.
+package gclitest
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/internal/helper"
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/cliutil/cmdline"
+	"github.com/gookit/goutil/x/ccolor"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+// HelpWidth the fixed width for wrap the help text on run, used on the App.HelpConfig.Width is not set.
+// so the help output does not change with the env COLUMNS or the terminal width.
+var HelpWidth = 80
+
+// runMu the runs change the process-level state(env vars, TTY, std streams) hold the
+// write lock, other runs hold the read lock.
+var runMu sync.RWMutex
+
+// Result of run an app
+type Result struct {
+	// Stdout the captured output contents. include the prompt questions. color codes are removed.
+	Stdout string
+	// Stderr the captured error output contents
+	Stderr string
+	// Code the exit code returned by App.Run
+	Code int
+	// Err the last error on run the app, nil on success
+	Err error
+}
+
+// Output get the stdout and stderr contents
+func (r *Result) Output() string { return r.Stdout + r.Stderr }
+
+// runOpts the options for run an app
+type runOpts struct {
+	stdin string
+	env   map[string]string
+	// tty fake the std streams are terminal, nil means not change.
+	tty *bool
+	// stdio capture the process std streams too.
+	stdio bool
+}
+
+// process check the run will change the process-level state
+func (o *runOpts) process() bool { return o.stdio || o.tty != nil || len(o.env) > 0 }
+
+// Option func for run an app
+type Option func(o *runOpts)
+
+// WithStdin set the input contents for the run. it will be read by the prompts of
+// option Question, Command.Confirm and the reads from c.In().
+//
+// Usage:
+//
+//	gclitest.Run(app, "user:add", gclitest.WithStdin("tom\n18\n"))
+func WithStdin(input string) Option {
+	return func(o *runOpts) { o.stdin = input }
+}
+
+// WithEnv set an env var for the run, will be restored after run.
+//
+// NOTE: the option default value from ENV(eg: "${APP_TOKEN}") is resolved on add the
+// command to app, so it should be set by os.Setenv(or t.Setenv) before create the app.
+func WithEnv(key, value string) Option {
+	return func(o *runOpts) {
+		if o.env == nil {
+			o.env = make(map[string]string)
+		}
+		o.env[key] = value
+	}
+}
+
+// WithEnvs set multi env vars for the run, will be restored after run.
+func WithEnvs(kvs map[string]string) Option {
+	return func(o *runOpts) {
+		for key, value := range kvs {
+			WithEnv(key, value)(o)
+		}
+	}
+}
+
+// WithTTY fake the input and outputs are(or not) terminal for the run. eg: for test the
+// pager or interactive confirm. by default, the captured streams are not terminal.
+//
+// NOTE: the help width is not detected from the terminal(or COLUMNS) on run, it is fixed
+// to HelpWidth if the App.HelpConfig.Width is not set. so the golden outputs are stable.
+func WithTTY(tty bool) Option {
+	return func(o *runOpts) { o.tty = &tty }
+}
+
+// WithStdio capture the process std streams too: os.Stdin, os.Stdout, os.Stderr, the
+// color output and cliutil.Input/Output are replaced while running, and the colors are
+// disabled. Use it for the commands print by fmt.Println or read from os.Stdin directly.
+func WithStdio() Option {
+	return func(o *runOpts) { o.stdio = true }
+}
+
+// Run the app with the input command line(not contains the bin name), returns the run result.
+//
+// Usage:
+//
+//	res := gclitest.Run(app, `greet --name "tom cat" -v`)
+func Run(app *gcli.App, line string, opts ...Option) *Result {
+	return RunArgs(app, cmdline.NewParser(line).Parse(), opts...)
+}
+
+// RunArgs run the app with the input args(not contains the bin name), returns the run result.
+func RunArgs(app *gcli.App, args []string, opts ...Option) *Result {
+	ro := &runOpts{}
+	for _, fn := range opts {
+		fn(ro)
+	}
+	if args == nil {
+		args = []string{} // nil will use os.Args
+	}
+
+	if ro.process() {
+		runMu.Lock()
+		defer runMu.Unlock()
+	} else {
+		runMu.RLock()
+		defer runMu.RUnlock()
+	}
+
+	restoreEnv := setEnvs(ro.env)
+	defer restoreEnv()
+
+	if ro.tty != nil {
+		oldFn := helper.IsTerminal
+		helper.IsTerminal = func(uintptr) bool { return *ro.tty }
+		defer func() { helper.IsTerminal = oldFn }()
+	}
+
+	cs, err := newCapture(app, ro.stdin, ro.stdio)
+	if err != nil {
+		return &Result{Code: gcli.ERR.ToInt(), Err: err}
+	}
+
+	// don't exit on the run end
+	oldExit := app.ExitFunc
+	app.ExitFunc = func(int) {}
+
+	// fixed help width, not changed by the env COLUMNS or terminal
+	oldWidth := app.HelpConfig.Width
+	if oldWidth == 0 {
+		app.HelpConfig.Width = HelpWidth
+	}
+
+	res := &Result{}
+	func() {
+		// restore the streams on panic
+		defer func() {
+			app.ExitFunc = oldExit
+			app.HelpConfig.Width = oldWidth
+			cs.restore()
+		}()
+		res.Code = app.Run(args)
+	}()
+
+	res.Stdout, res.Stderr = cs.output()
+	res.Err = app.LastError()
+	return res
+}
+
+// setEnvs set env vars and returns the restore func
+func setEnvs(env map[string]string) func() {
+	olds := make(map[string]*string, len(env))
+	for key, value := range env {
+		if old, ok := os.LookupEnv(key); ok {
+			olds[key] = &old
+		} else {
+			olds[key] = nil
+		}
+		_ = os.Setenv(key, value)
+	}
+
+	return func() {
+		for key, old := range olds {
+			if old == nil {
+				_ = os.Unsetenv(key)
+			} else {
+				_ = os.Setenv(key, *old)
+			}
+		}
+	}
+}
+
+// capture the app streams(and the process std streams on stdio) for a run
+type capture struct {
+	app   *gcli.App
+	stdio bool
+
+	oldIn, oldOut, oldErr *os.File
+	oldInput              io.Reader
+	oldOutput             io.Writer
+	oldColor              bool
+	oldLevel              termenv.ColorLevel
+
+	inR, outW, errW *os.File
+	outBuf, errBuf  bytes.Buffer
+	wg              sync.WaitGroup
+	restored        bool
+}
+
+func newCapture(app *gcli.App, stdin string, stdio bool) (*capture, error) {
+	cs := &capture{app: app, stdio: stdio}
+
+	// use pipes instead of buffers, so the fake TTY works and the pager can write to them
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cs.inR, cs.outW, cs.errW = inR, outW, errW
+
+	// write stdin in background, the pipe buffer is limited
+	go func() {
+		_, _ = io.Copy(inW, strings.NewReader(stdin))
+		_ = inW.Close()
+	}()
+
+	cs.wg.Add(2)
+	go cs.read(&cs.outBuf, outR)
+	go cs.read(&cs.errBuf, errR)
+
+	app.SetInput(inR)
+	app.SetOutput(outW, errW)
+
+	if stdio {
+		cs.oldIn, cs.oldOut, cs.oldErr = os.Stdin, os.Stdout, os.Stderr
+		cs.oldInput, cs.oldOutput = cliutil.Input, cliutil.Output
+		cs.oldColor, cs.oldLevel = color.Enable, ccolor.Level()
+
+		os.Stdin, os.Stdout, os.Stderr = inR, outW, errW
+		cliutil.Input, cliutil.Output = inR, outW
+		color.SetOutput(outW)
+		// no color codes in the captured output. ccolor is used by the cliutil prompts
+		color.Enable = false
+		ccolor.Disable()
+	}
+	return cs, nil
+}
+
+func (cs *capture) read(buf *bytes.Buffer, r *os.File) {
+	defer cs.wg.Done()
+	_, _ = io.Copy(buf, r)
+	_ = r.Close()
+}
+
+// restore the app and std streams, and wait the outputs are read.
+func (cs *capture) restore() {
+	if cs.restored {
+		return
+	}
+	cs.restored = true
+
+	cs.app.SetInput(nil)
+	cs.app.SetOutput(nil, nil)
+	if cs.stdio {
+		os.Stdin, os.Stdout, os.Stderr = cs.oldIn, cs.oldOut, cs.oldErr
+		cliutil.Input, cliutil.Output = cs.oldInput, cs.oldOutput
+		color.ResetOutput()
+		color.Enable = cs.oldColor
+		termenv.SetColorLevel(cs.oldLevel)
+	}
+
+	_ = cs.outW.Close()
+	_ = cs.errW.Close()
+	_ = cs.inR.Close()
+	cs.wg.Wait()
+}
+
+// output get the captured stdout and stderr contents, the color codes are removed.
+func (cs *capture) output() (stdout, stderr string) {
+	cs.restore()
+	return color.ClearCode(cs.outBuf.String()), color.ClearCode(cs.errBuf.String())
+}
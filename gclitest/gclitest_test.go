@@ -0,0 +1,198 @@
+package gclitest_test
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/gclitest"
+	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/goutil/x/assert"
+)
+
+func init() {
+	gcli.SetLocale("en")
+}
+
+func newTestApp() *gcli.App {
+	app := gcli.NewApp(func(a *gcli.App) {
+		a.Name = "demo"
+		a.Desc = "the demo app for gclitest"
+	})
+	app.EnablePager()
+
+	var name string
+	app.Add(&gcli.Command{
+		Name: "greet",
+		Desc: "greet someone",
+		Config: func(c *gcli.Command) {
+			c.StrOpt2(&name, "name,n", "the name", gflag.WithQuestion("your name? "))
+		},
+		Func: func(c *gcli.Command, _ []string) error {
+			color.Fprintf(c.Out(), "<info>hello %s</>\n", name)
+			if token := os.Getenv("DEMO_TOKEN"); token != "" {
+				fmt.Fprintln(c.Out(), "token:", token)
+			}
+			fmt.Fprintln(c.ErrOut(), "greet done")
+			return nil
+		},
+	})
+
+	app.Add(&gcli.Command{
+		Name: "fail",
+		Desc: "always failed",
+		Func: func(c *gcli.Command, _ []string) error {
+			return errors.New("something is wrong")
+		},
+	})
+
+	app.Add(&gcli.Command{
+		Name: "read",
+		Desc: "read lines from the input",
+		Func: func(c *gcli.Command, _ []string) error {
+			s := bufio.NewScanner(c.In())
+			for s.Scan() {
+				fmt.Fprintln(c.Out(), "line:", strings.ToUpper(s.Text()))
+			}
+			return nil
+		},
+	})
+
+	app.Add(&gcli.Command{
+		Name: "std",
+		Desc: "print by the process std streams",
+		Func: func(c *gcli.Command, _ []string) error {
+			s := bufio.NewScanner(os.Stdin)
+			for s.Scan() {
+				fmt.Println("std:", s.Text())
+			}
+			color.Infoln("by color")
+			fmt.Fprintln(os.Stderr, "std done")
+			return nil
+		},
+	})
+
+	app.Add(&gcli.Command{
+		Name: "list",
+		Desc: "list items by the pager",
+		Func: func(c *gcli.Command, _ []string) error {
+			p := c.Pager()
+			defer p.Close()
+			fmt.Fprintln(p, "item0\nitem1\nitem2")
+			return nil
+		},
+	})
+	return app
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	res := gclitest.Run(newTestApp(), `greet --name "tom cat"`)
+
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, 0, res.Code)
+	assert.Eq(t, "hello tom cat\n", res.Stdout)
+	assert.Eq(t, "greet done\n", res.Stderr)
+	assert.Eq(t, "hello tom cat\ngreet done\n", res.Output())
+}
+
+func TestRun_error(t *testing.T) {
+	t.Parallel()
+	res := gclitest.Run(newTestApp(), "fail")
+
+	assert.Err(t, res.Err)
+	assert.Eq(t, "something is wrong", res.Err.Error())
+	assert.Eq(t, 2, res.Code)
+	assert.StrContains(t, res.Stdout+res.Stderr, "something is wrong")
+
+	// not found command
+	res = gclitest.Run(newTestApp(), "not-exists")
+	assert.NotEq(t, 0, res.Code)
+	assert.StrContains(t, res.Output(), "not-exists")
+}
+
+func TestRun_ExitOnEnd(t *testing.T) {
+	app := newTestApp()
+	app.ExitOnEnd = true
+
+	res := gclitest.Run(app, "fail")
+	assert.Eq(t, 2, res.Code)
+	assert.Nil(t, app.ExitFunc)
+}
+
+func TestWithStdin(t *testing.T) {
+	t.Parallel()
+
+	// collect the option value by the question
+	res := gclitest.Run(newTestApp(), "greet", gclitest.WithStdin("inhere\n"))
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "your name? hello inhere\n", res.Stdout)
+
+	// read from c.In()
+	res = gclitest.Run(newTestApp(), "read", gclitest.WithStdin("abc\ndef\n"))
+	assert.Eq(t, "line: ABC\nline: DEF\n", res.Stdout)
+}
+
+func TestWithStdio(t *testing.T) {
+	t.Parallel()
+
+	res := gclitest.Run(newTestApp(), "std", gclitest.WithStdio(), gclitest.WithStdin("abc\n"))
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "std: abc\nby color\n", res.Stdout)
+	assert.Eq(t, "std done\n", res.Stderr)
+
+	// restored after run
+	assert.Eq(t, os.Stdout, gcli.NewApp().AppOpts().Out())
+}
+
+func TestWithEnv(t *testing.T) {
+	t.Parallel()
+
+	res := gclitest.Run(newTestApp(), "greet -n tom", gclitest.WithEnv("DEMO_TOKEN", "abc123"))
+	assert.Eq(t, "hello tom\ntoken: abc123\n", res.Stdout)
+	// restored after run
+	_, ok := os.LookupEnv("DEMO_TOKEN")
+	assert.False(t, ok)
+}
+
+func TestWithTTY(t *testing.T) {
+	t.Parallel()
+	envs := map[string]string{"LINES": "2", "GCLI_PAGER": "sed 's/^/> /'"}
+
+	// not a terminal: direct output
+	res := gclitest.Run(newTestApp(), "list", gclitest.WithEnvs(envs))
+	assert.Eq(t, "item0\nitem1\nitem2\n", res.Stdout)
+
+	// fake terminal: display by the pager
+	res = gclitest.Run(newTestApp(), "list", gclitest.WithEnvs(envs), gclitest.WithTTY(true))
+	assert.Eq(t, "> item0\n> item1\n> item2\n", res.Stdout)
+}
+
+func TestRun_helpWidth(t *testing.T) {
+	// not wrapped by the env COLUMNS
+	t.Setenv("COLUMNS", "40")
+	app := newTestApp()
+	gclitest.AssertGolden(t, "app-help", gclitest.Run(app, "").Stdout)
+	assert.Eq(t, 0, app.HelpConfig.Width)
+
+	app = newTestApp()
+	app.HelpConfig.Width = 40
+	res := gclitest.Run(app, "")
+	assert.StrContains(t, res.Stdout, "  --gen-completion  ")
+	assert.Eq(t, 40, app.HelpConfig.Width)
+}
+
+func TestAssertGolden(t *testing.T) {
+	t.Parallel()
+
+	res := gclitest.Run(newTestApp(), "greet -h")
+	assert.NoErr(t, res.Err)
+	res.AssertGolden(t, "greet-help")
+
+	gclitest.AssertGolden(t, "app-help", gclitest.Run(newTestApp(), "").Stdout)
+}
@@ -0,0 +1,82 @@
+package gclitest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// GoldenDir the dir of the golden files, relative to the test package dir.
+var GoldenDir = "testdata"
+
+// UpdateFlag the test flag name for update golden files. eg: go test ./cmd -update
+const UpdateFlag = "update"
+
+func init() {
+	// the flag maybe defined by other test helper packages
+	if flag.Lookup(UpdateFlag) == nil {
+		flag.Bool(UpdateFlag, false, "update the golden files of gclitest")
+	}
+}
+
+// updateGolden check the -update flag is set
+func updateGolden() bool {
+	f := flag.Lookup(UpdateFlag)
+	return f != nil && f.Value.String() == "true"
+}
+
+// AssertGolden compare the actual contents with the golden file {GoldenDir}/{name}.golden.
+//
+// Run tests with the `-update` flag to create or update the golden files.
+func AssertGolden(t testing.TB, name, actual string) {
+	t.Helper()
+	file := filepath.Join(GoldenDir, name+".golden")
+
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("gclitest: create golden dir error: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(actual), 0644); err != nil {
+			t.Fatalf("gclitest: update golden file error: %v", err)
+		}
+		return
+	}
+
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("gclitest: read golden file error: %v (run tests with -%s to create it)", err, UpdateFlag)
+	}
+
+	if want := string(bs); want != actual {
+		t.Errorf("gclitest: the output is not match the golden file %s (run tests with -%s to update it)\n%s",
+			file, UpdateFlag, diffLine(want, actual))
+	}
+}
+
+// AssertGolden compare the stdout with the golden file. see AssertGolden
+func (r *Result) AssertGolden(t testing.TB, name string) {
+	t.Helper()
+	AssertGolden(t, name, r.Stdout)
+}
+
+// diffLine describe the first different line of the want and got contents.
+func diffLine(want, got string) string {
+	wls, gls := strings.Split(want, "\n"), strings.Split(got, "\n")
+	line := func(ls []string, i int) string {
+		if i < len(ls) {
+			return strconv.Quote(ls[i])
+		}
+		return "<EOF>"
+	}
+
+	for i := 0; i < len(wls) || i < len(gls); i++ {
+		if w, g := line(wls, i), line(gls, i); w != g {
+			return fmt.Sprintf("first diff at line %d:\n  want: %s\n  got:  %s", i+1, w, g)
+		}
+	}
+	return ""
+}
@@ -1,72 +1,146 @@
 package gevent
 
+// payload data keys for the hook events. see the "Data" doc on each event.
+//
+// TIP: use the typed accessors on gcli.HookCtx first. eg: ctx.Args(), ctx.Err(), ctx.CmdPath()
+const (
+	// KeyArgs the args []string
+	KeyArgs = "args"
+	// KeyErr the error value
+	KeyErr = "err"
+	// KeyName the input command name string
+	KeyName = "name"
+	// KeyRaw the raw input command name string
+	KeyRaw = "raw"
+	// KeyCmd the command name string
+	KeyCmd = "cmd"
+	// KeyCode the exit code int
+	KeyCode = "code"
+)
+
 // constants for hooks event, there are default allowed event names
+//
+// TIP: the HookCtx.Cmd is set on all cmd.* events and the app events that with a command.
 const (
 	// OnAppInitBefore On app init before
+	//
+	// Data: none
 	OnAppInitBefore = "app.init.before"
 	// OnAppInitAfter On app init after
+	//
+	// Data: none
 	OnAppInitAfter = "app.init.after"
 	// OnAppExit On app exit before
+	//
+	// Data:
+	// 	{code: exit-code(int)}
 	OnAppExit = "app.exit"
 
 	// OnAppBindOptsBefore before bind app options
+	//
+	// Data: none
 	OnAppBindOptsBefore = "app.bind.opts.before"
 	// OnAppBindOptsAfter after bind app options.
 	//
 	// support binding custom global options
+	//
+	// Data: none
 	OnAppBindOptsAfter = "app.bind.opts.after"
 
-	// OnAppCmdAdd on app cmd add
+	// OnAppCmdAdd on app cmd add. ctx.Cmd is the adding command.
+	//
+	// Data: none
 	OnAppCmdAdd = "app.cmd.add.before"
 
-	// OnAppCmdAdded on app cmd added
+	// OnAppCmdAdded on app cmd added. ctx.Cmd is the added command.
+	//
+	// Data: none
 	OnAppCmdAdded = "app.cmd.added"
 
 	// OnAppOptsParsed event
 	//
 	// Data:
-	// 	{args: app-args}
+	// 	{args: app-args([]string)}
 	OnAppOptsParsed = "app.opts.parsed"
 
 	// OnAppHelpBefore event. before on render help
+	//
+	// Data: none
 	OnAppHelpBefore = "app.help.before"
 	// OnAppHelpAfter event. after on render help
+	//
+	// Data: none
 	OnAppHelpAfter = "app.help.after"
 
 	// OnAppPrepared prepare for run, after the OnAppOptsParsed
+	//
+	// Data:
+	// 	{name: command-name(string)}
 	OnAppPrepared = "app.run.prepared"
 
-	// OnAppRunBefore app run before, after the OnAppPrepared
+	// OnAppRunBefore app run before, after the OnAppPrepared. ctx.Cmd is the running command.
+	//
+	// Data:
+	// 	{cmd: command-name(string), args: command-args([]string)}
 	OnAppRunBefore = "app.run.before"
-	OnAppRunAfter  = "app.run.after"
-	OnAppRunError  = "app.run.error"
+	// OnAppRunAfter app run success. ctx.Cmd is the running command, nil on run App.Func.
+	//
+	// Data:
+	// 	{cmd: command-name(string)}
+	OnAppRunAfter = "app.run.after"
+	// OnAppRunError app run error. ctx.Cmd is the running command, nil on run App.Func.
+	//
+	// Data:
+	// 	{cmd: command-name(string), err: error}
+	OnAppRunError = "app.run.error"
 
+	// OnCmdInitBefore command init before
+	//
+	// Data: none
 	OnCmdInitBefore = "cmd.init.before"
-	OnCmdInitAfter  = "cmd.init.after"
+	// OnCmdInitAfter command init after
+	//
+	// Data: none
+	OnCmdInitAfter = "cmd.init.after"
 
 	// OnCmdNotFound on top-command or subcommand not found.
 	//
-	// Ctx:
-	// 	{"name": name, "args": []string}
+	// TIP: the not-found listener can report an error by ctx.WithErr(), it will be the run error.
+	//
+	// Data:
+	// 	{name: input-name(string), args: remain-args([]string)}
 	OnCmdNotFound = "cmd.not.found"
 
 	// OnAppCmdNotFound on top command not found.
-	// ctx: {"name": name, "args": []string}
+	//
+	// Data:
+	// 	{name: input-name(string), raw: raw-input-name(string), args: remain-args([]string)}
 	OnAppCmdNotFound = "app.cmd.not.found"
-	// OnCmdSubNotFound on subcommand not found.
-	// ctx: {"name": name, "args": []string}
+	// OnCmdSubNotFound on subcommand not found. ctx.Cmd is the parent command.
+	//
+	// Data:
+	// 	{name: input-name(string), args: remain-args([]string)}
 	OnCmdSubNotFound = "cmd.sub.not.found"
 
 	// OnCmdOptParsed event
 	//
 	// Data:
-	// 	{args: command-args}
+	// 	{args: command-args([]string)}
 	OnCmdOptParsed = "cmd.opts.parsed"
 
 	// OnCmdRunBefore cmd run, flags has been parsed.
+	//
+	// Data:
+	// 	{args: command-args([]string)}
 	OnCmdRunBefore = "cmd.run.before"
 	// OnCmdRunAfter after cmd success run
+	//
+	// Data: none
 	OnCmdRunAfter = "cmd.run.after"
+	// OnCmdRunError cmd run error
+	//
+	// Data:
+	// 	{cmd: command-name(string), err: error}
 	OnCmdRunError = "cmd.run.error"
 
 	// OnCmdExecBefore cmd exec
@@ -77,6 +151,6 @@ const (
 	// OnGlobalOptsParsed app or cmd parsed the global options
 	//
 	// Data:
-	// 	{args: remain-args}
+	// 	{args: remain-args([]string)}
 	OnGlobalOptsParsed = "gcli.gopts.parsed"
 )
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/gcli/v3/internal/helper"
 	"github.com/gookit/goutil/errorx"
 	"github.com/gookit/goutil/mathutil"
@@ -72,7 +73,7 @@ func (ags *CliArgs) ParseArgs(args []string) (err error) {
 		num = i + 1
 		if num > inNum { // not enough args
 			if arg.Required {
-				return errorx.Rawf("must set value for the argument: %s(position#%d)", arg.ShowName, arg.index)
+				return errorx.Raw(gi18n.T("err.argRequired", arg.ShowName, arg.index))
 			}
 			num = i
 			break
@@ -93,7 +94,7 @@ func (ags *CliArgs) ParseArgs(args []string) (err error) {
 
 	if inNum > num {
 		if ags.validateNum {
-			return errorx.Rawf("entered too many arguments: %v", args[num:])
+			return errorx.Raw(gi18n.T("err.tooManyArgs", args[num:]))
 		}
 		ags.remainArgs = args[num:]
 	}
@@ -235,31 +236,30 @@ func (ags *CliArgs) String() string {
 	return ags.BuildArgsHelp()
 }
 
-// BuildArgsHelp string
+// BuildArgsHelp string. will wrap the descriptions to the auto-detected width. see ResolveHelpWidth
 func (ags *CliArgs) BuildArgsHelp() string {
+	return ags.BuildArgsHelpWidth(ResolveHelpWidth(0))
+}
+
+// BuildArgsHelpWidth build args help string, wrap the descriptions to the width. width <= 0 for not wrap.
+func (ags *CliArgs) BuildArgsHelpWidth(width int) string {
 	if len(ags.args) < 1 {
 		return ""
 	}
 
-	indent := strutil.Repeat(" ", ags.argWidth+3)
+	// the description column. "  " + name + " "
+	descCol := ags.argWidth + 3
 
 	var sb strings.Builder
 	for _, arg := range ags.args {
 		sb.WriteString(fmt.Sprintf(
-			"  <info>%s</> %s",
+			"  <info>%s</> ",
 			strutil.PadRight(arg.HelpName(), " ", ags.argWidth),
-			requiredMark(arg.Required),
 		))
 
-		// multi lines
-		if strings.ContainsRune(arg.Desc, '\n') {
-			first, other := strutil.QuietCut(arg.Desc, "\n")
-			sb.WriteString(strutil.UpperFirst(first))
-			sb.WriteByte('\n')
-			sb.WriteString(strutil.Indent(other, indent))
-		} else {
-			sb.WriteString(strutil.UpperFirst(arg.Desc))
-		}
+		// multi lines and wrap to width
+		desc := requiredMark(arg.Required) + strutil.UpperFirst(arg.Desc)
+		sb.WriteString(WrapText(desc, descCol, width))
 		sb.WriteByte('\n')
 	}
 
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/goutil/cflag"
 )
 
@@ -51,6 +52,74 @@ type FlagSet struct {
 	//
 	// eg. {"n": "name", "o": "opt"}
 	shorts map[string]string
+	// implicit values for optional-value options. format: {name: implicitValue}
+	//
+	// eg. {"color": "auto"} - `--color` = `--color=auto`
+	optionals map[string]string
+	// long alias names map for options. format: {alias: name}
+	//
+	// eg. {"dryrun": "dry-run"}
+	aliases map[string]string
+	// deprecated option names or alias names. format: {name: message}
+	//
+	// eg. {"out": "use '--output' instead"}
+	deprecated map[string]string
+	// onDeprecated will call on a deprecated option or alias name is used.
+	onDeprecated func(name, msg string)
+}
+
+// addAlias add an alias name for the option(full name).
+func (f *FlagSet) addAlias(alias, name string) {
+	if f.aliases == nil {
+		f.aliases = make(map[string]string)
+	}
+	f.aliases[alias] = name
+}
+
+// setDeprecated mark the option name or alias name is deprecated, with a message.
+func (f *FlagSet) setDeprecated(name, msg string) {
+	if f.deprecated == nil {
+		f.deprecated = make(map[string]string)
+	}
+	f.deprecated[name] = msg
+}
+
+// resolveName resolve the short or alias name to the option full name.
+func (f *FlagSet) resolveName(name string) string {
+	if rName, ok := f.shorts[name]; ok {
+		return rName
+	}
+	if rName, ok := f.aliases[name]; ok {
+		return rName
+	}
+	return name
+}
+
+// checkDeprecated call the onDeprecated handler when the used name or resolved name is deprecated.
+func (f *FlagSet) checkDeprecated(used, name string) {
+	if f.onDeprecated == nil {
+		return
+	}
+
+	if msg, ok := f.deprecated[used]; ok {
+		f.onDeprecated(used, msg)
+	} else if msg, ok := f.deprecated[name]; ok {
+		f.onDeprecated(name, msg)
+	}
+}
+
+// setOptional mark the option value is optional, use implicit value when given bare.
+func (f *FlagSet) setOptional(name, implicit string) {
+	if f.optionals == nil {
+		f.optionals = make(map[string]string)
+	}
+	f.optionals[name] = implicit
+}
+
+// isOptional reports whether the option(full name) value is optional.
+func (f *FlagSet) isOptional(name string) bool {
+	_, ok := f.optionals[name]
+	return ok
 }
 
 // NewFlagSet create a new FlagSet
@@ -321,7 +390,7 @@ func (f *FlagSet) parseOne() (bool, error) {
 	}
 	name := s[numMinuses:]
 	if len(name) == 0 || name[0] == '-' || name[0] == '=' {
-		return false, fmt.Errorf("bad flag syntax: %s", s)
+		return false, errors.New(gi18n.T("err.badFlagSyntax", s))
 	}
 
 	// it's a flag. does it have an argument?
@@ -337,10 +406,9 @@ func (f *FlagSet) parseOne() (bool, error) {
 		}
 	}
 
-	// resolve shortcut name
-	if rName, ok := f.shorts[name]; ok {
-		name = rName
-	}
+	// resolve shortcut or alias name
+	usedName := name
+	name = f.resolveName(name)
 
 	flg, ok := f.formal[name]
 	if !ok {
@@ -348,13 +416,14 @@ func (f *FlagSet) parseOne() (bool, error) {
 			// f.usage()
 			return false, flag.ErrHelp
 		}
-		return false, fmt.Errorf("option provided but not defined: %s", cflag.AddPrefix(name))
+		return false, errors.New(gi18n.T("err.optNotDefined", cflag.AddPrefix(name)))
 	}
+	f.checkDeprecated(usedName, name)
 
 	if fv, ok := flg.Value.(boolFlag); ok && fv.IsBoolFlag() { // special case: doesn't need an arg
 		if hasValue {
 			if err := fv.Set(value); err != nil {
-				return false, fmt.Errorf("invalid boolean value %q for %s: %v", value, cflag.AddPrefix(name), err)
+				return false, errors.New(gi18n.T("err.optInvalidBool", value, cflag.AddPrefix(name), err))
 			}
 		} else {
 			if err := fv.Set("true"); err != nil {
@@ -362,6 +431,12 @@ func (f *FlagSet) parseOne() (bool, error) {
 			}
 		}
 	} else {
+		// optional-value option: given bare, use the implicit value. never consume the next arg.
+		if implicit, ok := f.optionals[name]; ok && !hasValue {
+			hasValue = true
+			value = implicit
+		}
+
 		// It must have a value, which might be the next argument.
 		if !hasValue && len(f.args) > 0 {
 			// value is the next arg
@@ -369,10 +444,10 @@ func (f *FlagSet) parseOne() (bool, error) {
 			value, f.args = f.args[0], f.args[1:]
 		}
 		if !hasValue {
-			return false, fmt.Errorf("flag option needs an argument: %s", cflag.AddPrefix(name))
+			return false, errors.New(gi18n.T("err.optNeedsArgument", cflag.AddPrefix(name)))
 		}
 		if err := flg.Value.Set(value); err != nil {
-			return false, fmt.Errorf("invalid value %q for option %s: %v", value, cflag.AddPrefix(name), err)
+			return false, errors.New(gi18n.T("err.optInvalidValue", value, cflag.AddPrefix(name), err))
 		}
 	}
 
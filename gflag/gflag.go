@@ -11,8 +11,6 @@ const (
 	AlignLeft = strutil.PosRight
 	// AlignRight Align right, padding left
 	AlignRight = strutil.PosLeft
-	// default desc
-	defaultDesc = "No description"
 )
 
 const (
@@ -99,6 +97,12 @@ type Config struct {
 	// Set true to keep the strict, std-flag behavior (stop options parsing at the
 	// first positional argument).
 	DisableReorderArgs bool
+	// HelpWidth the width for wrap the option and argument descriptions on print help.
+	//
+	// 	0  - auto detect by the env COLUMNS or the terminal width. default
+	// 	>0 - wrap to the width
+	// 	<0 - disable wrap
+	HelpWidth int
 }
 
 // GetTagName get tag name, default is FlagTagName
@@ -116,6 +120,13 @@ func WithIndentLongOpt(yes bool) ConfigFunc {
 	}
 }
 
+// WithHelpWidth set the width for wrap help text. 0 for auto detect, <0 to disable wrap.
+func WithHelpWidth(width int) ConfigFunc {
+	return func(cfg *Config) {
+		cfg.HelpWidth = width
+	}
+}
+
 // WithReorderArgs enable or disable the auto-reorder of input args. default is enabled.
 func WithReorderArgs(enable bool) ConfigFunc {
 	return func(cfg *Config) {
@@ -9,11 +9,17 @@ import (
 
 	"github.com/gookit/gcli/v3"
 	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/goutil/dump"
 	"github.com/gookit/goutil/testutil"
 	"github.com/gookit/goutil/x/assert"
 )
 
+func init() {
+	// the tests check the english messages, ignore the locale from env
+	gi18n.SetLocale("en")
+}
+
 func TestFlags_Basic(t *testing.T) {
 	fs := gflag.New("testFlags")
 
@@ -670,6 +676,18 @@ func TestFlags_PrintHelpPanel_EnvDefaultDoesNotExposeValue(t *testing.T) {
 	assert.NotContains(t, help, "real-secret-token")
 }
 
+func TestCliOpt_EnvVar(t *testing.T) {
+	var token, user, name string
+	fs := gflag.New("test")
+	fs.StrOpt(&token, "token", "", "${SERVER_TOKEN}", "server token")
+	fs.StrOpt(&user, "user", "", "${DB_USER | root}", "db user")
+	fs.StrOpt(&name, "name", "", "tom", "the name")
+
+	assert.Eq(t, "SERVER_TOKEN", fs.Opt("token").EnvVar())
+	assert.Eq(t, "DB_USER", fs.Opt("user").EnvVar())
+	assert.Eq(t, "", fs.Opt("name").EnvVar())
+}
+
 func TestFlags_PrintHelpPanel_IndentLongOpt(t *testing.T) {
 	fs := gflag.New("test").WithConfigFn(func(c *gflag.Config) {
 		c.IndentLongOpt = true
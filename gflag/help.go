@@ -3,11 +3,18 @@ package gflag
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/goutil/cflag"
+	"github.com/gookit/goutil/cliutil"
 	"github.com/gookit/goutil/strutil"
 )
 
@@ -39,12 +46,12 @@ func (p *Parser) BuildHelp() string {
 
 	// repeat call the method
 	if p.buf.Len() < 1 {
-		p.buf.WriteString("Options:\n")
+		p.buf.WriteString(gi18n.T("help.options") + ":\n")
 		p.buf.WriteString(p.BuildOptsHelp())
 		p.buf.WriteByte('\n')
 
 		if p.HasArgs() {
-			p.buf.WriteString("Arguments:\n")
+			p.buf.WriteString(gi18n.T("help.arguments") + ":\n")
 			p.buf.WriteString(p.BuildArgsHelp())
 			p.buf.WriteByte('\n')
 		}
@@ -130,10 +137,10 @@ func (p *Parser) buildGroupedOptsHelp() string {
 func (p *Parser) formatOneFlag(f *Flag) (s string) {
 	// Skip render:
 	// - opt is not exists (Has ensured that it is not a short name)
-	// - it is a hidden flag option
+	// - it is a hidden or deprecated flag option
 	// - flag desc is empty
 	opt, has := p.opts[f.Name]
-	if !has || opt.Hidden {
+	if !has || !opt.Visible() {
 		return
 	}
 
@@ -144,15 +151,11 @@ func (p *Parser) formatOneFlag(f *Flag) (s string) {
 	// display description on new line
 	descNl := p.cfg.DescNewline
 
-	var nlIndent string
-	if descNl {
-		nlIndent = "\n        "
-	} else {
-		nlIndent = "\n      " + strings.Repeat(" ", p.optMaxLen)
-	}
+	// the description column. also the hanging indent on wrap
+	descCol := 6 + p.optMaxLen
 
 	// add prefix '-' to option
-	fullName = cflag.AddPrefixes2(name, opt.Shorts, true)
+	fullName = opt.appendAliases(cflag.AddPrefixes2(name, opt.Shorts, true))
 	if p.hasShort && p.cfg.IndentLongOpt && fullName[1] == '-' {
 		nameLen += 4
 		fullName = "    " + fullName
@@ -163,7 +166,15 @@ func (p *Parser) formatOneFlag(f *Flag) (s string) {
 	// - build flag type info
 	typeName, desc := UnquoteUsage(f)
 	// typeName: option value data type: int, string, ..., bool value will return ""
-	if !p.cfg.WithoutType && len(typeName) > 0 {
+	if p.cfg.WithoutType {
+		typeName = ""
+	}
+	// optional-value option, append implicit value hint. eg: `--color string[="auto"]`
+	if opt.ValueOptional {
+		typeName += fmt.Sprintf("[=%q]", opt.ImplicitValue)
+	}
+
+	if len(typeName) > 0 {
 		typeLen := len(typeName) + 1
 		if !descNl && nameLen+typeLen > p.optMaxLen {
 			descNl = true
@@ -175,7 +186,8 @@ func (p *Parser) formatOneFlag(f *Flag) (s string) {
 	}
 
 	if descNl {
-		s += nlIndent
+		descCol = 8
+		s += "\n" + strings.Repeat(" ", descCol)
 	} else {
 		// padding space to optMaxLen width.
 		if padLen := p.optMaxLen - nameLen; padLen > 0 {
@@ -186,25 +198,203 @@ func (p *Parser) formatOneFlag(f *Flag) (s string) {
 
 	// -- build description
 	if desc == "" {
-		desc = defaultDesc
+		desc = gi18n.T("help.noDesc")
 	} else {
-		desc = strings.Replace(strutil.UpperFirst(desc), "\n", nlIndent, -1)
+		desc = strutil.UpperFirst(desc)
 	}
-
-	s += requiredMark(opt.Required) + desc
+	desc = requiredMark(opt.Required) + desc
 
 	// ---- append default value
 	if isZero, _ := cflag.IsZeroValue(f, f.DefValue); !isZero {
 		// env value, show env name by opt.DefVal
 		defVal := opt.defaultPlaceholder(f.DefValue)
-		s += fmt.Sprintf(" (default <magentaB>%s</>)", defVal)
+		desc += fmt.Sprintf(" (%s <magentaB>%s</>)", gi18n.T("help.default"), defVal)
 	} else if opt.defEnvVar != "" {
-		s += fmt.Sprintf(" (default <magentaB>%s</>)", opt.defEnvVar)
+		desc += fmt.Sprintf(" (%s <magentaB>%s</>)", gi18n.T("help.default"), opt.defEnvVar)
 	}
 
 	// arrayed, repeatable
 	if _, ok := f.Value.(cflag.RepeatableFlag); ok {
-		s += " <cyan>(repeatable)</>"
+		desc += " <cyan>(" + gi18n.T("help.repeatable") + ")</>"
+	}
+
+	// wrap to the help width, align the new lines to the description column
+	return s + WrapText(desc, descCol, p.HelpWidth())
+}
+
+/***********************************************************************
+ * Flags:
+ * - wrap help text to terminal width
+ ***********************************************************************/
+
+// minWrapWidth the min available width for wrap text, will not wrap if less than it.
+const minWrapWidth = 20
+
+// HelpWidth get the width for wrap help text. see ResolveHelpWidth
+func (p *Parser) HelpWidth() int { return ResolveHelpWidth(p.cfg.HelpWidth) }
+
+// BuildArgsHelp string. wrap the descriptions to the parser help width.
+func (p *Parser) BuildArgsHelp() string { return p.CliArgs.BuildArgsHelpWidth(p.HelpWidth()) }
+
+// ResolveHelpWidth resolve the width for wrap help text.
+//
+//   - width > 0: use it.
+//   - width < 0: disable wrap, returns 0.
+//   - width = 0: auto detect by the env COLUMNS, then the terminal width.
+//     returns 0(not wrap) on the output is not a terminal.
+func ResolveHelpWidth(width int) int {
+	if width != 0 {
+		return max(width, 0)
+	}
+
+	if cols := os.Getenv("COLUMNS"); cols != "" {
+		if w, err := strconv.Atoi(cols); err == nil && w > 0 {
+			return w
+		}
+	}
+
+	w, _ := cliutil.GetTermSize()
+	return max(w, 0)
+}
+
+// match color tag or ANSI escape code at the start. eg: "<info>", "</>", "<fg=red;op=bold>", "\x1b[0;32m"
+var ctrlTagRe = regexp.MustCompile(`^(?:</?[a-zA-Z][\w=,;]*>|</>|\x1b\[[0-9;]*m)`)
+
+// TextWidth get the display width of the help text. ignore color tags, ANSI codes and support wide chars(eg: CJK).
+func TextWidth(s string) (w int) {
+	for i := 0; i < len(s); {
+		if m := ctrlTagRe.FindString(s[i:]); m != "" {
+			i += len(m)
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w += runeWidth(r)
+		i += size
+	}
+	return
+}
+
+func runeWidth(r rune) int {
+	if w := strutil.RuneWidth(r); w > 0 {
+		return w
+	}
+	// the neutral chars(eg: 'é') are 0, but printable chars should be 1
+	if unicode.IsPrint(r) {
+		return 1
+	}
+	return 0
+}
+
+// WrapText word-wrap the help text to width with hanging indent.
+//
+// The text is started at column indent, and all continuation lines are indented by indent spaces.
+// Keep the original newlines; long wide chars(eg: CJK) text can be broken at any char.
+// Color tags(eg: "<info>") and ANSI codes are not counted to width.
+//
+// returns the original text on width <= 0 or the available width is too small.
+func WrapText(s string, indent, width int) string {
+	avail := width - indent
+	if width <= 0 || avail < minWrapWidth {
+		return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", indent))
+	}
+
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		lines = append(lines, wrapLine(line, avail)...)
+	}
+	return strings.Join(lines, "\n"+strings.Repeat(" ", indent))
+}
+
+// wrapUnit a unbreakable part of the text. a word or a wide char.
+type wrapUnit struct {
+	text  string
+	width int
+	// has space before the unit
+	space bool
+}
+
+// splitWrapUnits split a line to wrap units.
+func splitWrapUnits(line string) []wrapUnit {
+	var units []wrapUnit
+	var cur wrapUnit
+	var space bool // has pending space before the next unit
+
+	add := func(s string, w int) {
+		if cur.text == "" {
+			cur.space, space = space, false
+		}
+		cur.text += s
+		cur.width += w
+	}
+	flush := func() {
+		if cur.text != "" {
+			units = append(units, cur)
+			cur = wrapUnit{}
+		}
+	}
+
+	for i := 0; i < len(line); {
+		// color tag or ANSI code: zero width, keep with the current word
+		if m := ctrlTagRe.FindString(line[i:]); m != "" {
+			add(m, 0)
+			i += len(m)
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+
+		switch rw := runeWidth(r); {
+		case r == ' ' || r == '\t':
+			flush()
+			space = true
+		case rw > 1:
+			// wide char as a single unit, can be broken at any char
+			flush()
+			add(string(r), rw)
+			flush()
+		default:
+			add(string(r), rw)
+		}
 	}
-	return s
+
+	flush()
+	return units
+}
+
+// wrapLine wrap one line(without newline) to avail width
+func wrapLine(line string, avail int) []string {
+	if TextWidth(line) <= avail {
+		return []string{line}
+	}
+
+	// keep the leading spaces on the first line
+	trimmed := strings.TrimLeft(line, " ")
+	lead := line[:len(line)-len(trimmed)]
+
+	var lines []string
+	var sb strings.Builder
+	sb.WriteString(lead)
+	curW := len(lead)
+	for _, u := range splitWrapUnits(trimmed) {
+		need := u.width
+		if u.space && curW > 0 {
+			need++
+		}
+
+		if curW > 0 && curW+need > avail {
+			lines = append(lines, sb.String())
+			sb.Reset()
+			curW, need = 0, u.width
+		}
+
+		if u.space && curW > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(u.text)
+		curW += need
+	}
+
+	return append(lines, sb.String())
 }
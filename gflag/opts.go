@@ -1,11 +1,14 @@
 package gflag
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/gcli/v3/internal/helper"
 	"github.com/gookit/goutil/cflag"
 	"github.com/gookit/goutil/cliutil"
@@ -23,6 +26,9 @@ const (
 // DefaultOptWidth for render help
 var DefaultOptWidth = 20
 
+// MaxValueFileSize the max bytes of an option value read from file or stdin. see CliOpt.ValueFromFile
+var MaxValueFileSize int64 = 1 << 20
+
 // CliOpts cli options management
 type CliOpts struct {
 	// name inherited from gcli.Command
@@ -47,6 +53,18 @@ type CliOpts struct {
 	shorts map[string]string
 	// exist short names. useful for render help
 	hasShort bool
+	// in, out for the option Question prompts and read the value "-" from stdin,
+	// errOut for the deprecated warnings. see SetIO
+	in     io.Reader
+	out    io.Writer
+	errOut io.Writer
+}
+
+// SetIO set the input and output for the option Question prompts and read the value "-" from stdin,
+// and the error output for the deprecated warnings.
+// nil for the default: cliutil.Input, cliutil.Output, os.Stderr
+func (co *CliOpts) SetIO(in io.Reader, out, errOut io.Writer) {
+	co.in, co.out, co.errOut = in, out, errOut
 }
 
 // InitFlagSet create and init flag.FlagSet
@@ -415,7 +433,7 @@ func (co *CliOpts) varOpt(v flag.Value, opt *CliOpt) {
 func (co *CliOpts) checkFlagInfo(opt *CliOpt) string {
 	// check flag name
 	name := opt.initCheck()
-	if _, ok := co.opts[name]; ok {
+	if _, ok := co.opts[name]; ok || co.isAliasName(name) {
 		// co.name 由 gcli.Command 注入(命令全路径), 带上它便于定位是哪个命令重复绑定
 		if co.name != "" {
 			panicf("redefined option flag '%s' in command '%s'", name, co.name)
@@ -433,13 +451,28 @@ func (co *CliOpts) checkFlagInfo(opt *CliOpt) string {
 	// is a short name
 	helpLen := opt.helpNameLen()
 	// fix: must exclude Hidden option
-	if !opt.Hidden {
+	if opt.Visible() {
 		// +6: type placeholder width
 		co.optMaxLen = mathutil.MaxInt(co.optMaxLen, helpLen+6)
 	}
 
+	// the value file is read after the value is set, so only the string value is allowed.
+	if opt.ValueFromFile && opt.flagType != FlagTypeString {
+		panicf("option '%s': ValueFromFile only support the string option, got type %s", name, opt.flagType)
+	}
+
 	// check short names
 	co.checkShortNames(name, opt.Shorts)
+	// record implicit value for the optional-value option
+	if opt.ValueOptional {
+		co.fSet.setOptional(name, opt.ImplicitValue)
+	}
+	// record deprecated option and old names
+	if opt.Deprecated != "" {
+		co.fSet.setDeprecated(name, opt.Deprecated)
+	}
+	co.checkAliasNames(name, opt.Aliases, false)
+	co.checkAliasNames(name, opt.DeprecatedNames, true)
 
 	// update name length
 	co.names[name] = helpLen
@@ -497,6 +530,9 @@ func (co *CliOpts) checkShortNames(name string, shorts []string) {
 		if n, ok := co.shorts[short]; ok {
 			panicf("short name '%s' has been used by option '%s'", short, n)
 		}
+		if co.isAliasName(short) {
+			panicf("short name '%s' has been used as an option alias name", short)
+		}
 
 		// storage short name
 		co.shorts[short] = name
@@ -506,6 +542,36 @@ func (co *CliOpts) checkShortNames(name string, shorts []string) {
 	co.fSet.shorts = co.shorts
 }
 
+// check and record long alias names for the option. deprecated is true for the old names.
+func (co *CliOpts) checkAliasNames(name string, aliases []string, deprecated bool) {
+	for _, alias := range aliases {
+		alias = strings.Trim(alias, "- ")
+		if !helper.IsGoodName(alias) {
+			panicf("option alias name '%s' is invalid, must match: %s", alias, helper.RegGoodName)
+		}
+		if alias == name {
+			panicf("option alias name '%s' has been used as the current option name", alias)
+		}
+		if co.HasOption(alias) || co.IsShortName(alias) || co.isAliasName(alias) {
+			panicf("option alias name '%s' has been used by option '%s'", alias, co.fSet.resolveName(alias))
+		}
+
+		co.fSet.addAlias(alias, name)
+		if deprecated {
+			co.fSet.setDeprecated(alias, fmt.Sprintf("use '%s' instead", cflag.AddPrefix(name)))
+		}
+	}
+}
+
+// isAliasName check it is an alias name of an option
+func (co *CliOpts) isAliasName(name string) bool {
+	if co.fSet == nil {
+		return false
+	}
+	_, ok := co.fSet.aliases[name]
+	return ok
+}
+
 /***********************************************************************
  * Options parse
  ***********************************************************************/
@@ -524,7 +590,7 @@ func (co *CliOpts) ParseOpts(args []string) (err error) {
 func (co *CliOpts) validateAll() error {
 	for name, opt := range co.opts {
 		fItem := co.fSet.Lookup(name)
-		if err := opt.Validate(fItem.Value.String()); err != nil {
+		if err := opt.validate(fItem.Value.String(), co.in, co.out); err != nil {
 			return err
 		}
 	}
@@ -639,6 +705,44 @@ func WithChoices(choices ...string) CliOptFn {
 	return func(opt *CliOpt) { opt.Choices = choices }
 }
 
+// WithDeprecated mark the option is deprecated, with a message. see CliOpt.Deprecated
+//
+// eg: WithDeprecated("use --output instead")
+func WithDeprecated(msg string) CliOptFn {
+	return func(opt *CliOpt) { opt.Deprecated = msg }
+}
+
+// WithAliases add long alias names for the option. see CliOpt.Aliases
+func WithAliases(names ...string) CliOptFn {
+	return func(opt *CliOpt) { opt.Aliases = append(opt.Aliases, names...) }
+}
+
+// WithDeprecatedNames add hidden, deprecated alias names(old names) for the option.
+// see CliOpt.DeprecatedNames
+func WithDeprecatedNames(names ...string) CliOptFn {
+	return func(opt *CliOpt) { opt.DeprecatedNames = append(opt.DeprecatedNames, names...) }
+}
+
+// WithOptionalValue setting the option value is optional, implicit is used when given bare.
+// see CliOpt.ValueOptional
+//
+// eg: WithOptionalValue("auto") - `--color` = `--color=auto`, `--color=always` set "always"
+func WithOptionalValue(implicit string) CliOptFn {
+	return func(opt *CliOpt) {
+		opt.ValueOptional = true
+		opt.ImplicitValue = implicit
+	}
+}
+
+// WithValueFromFile allow read the option value from file or stdin. see CliOpt.ValueFromFile
+//
+// NOTE: only for the string option, bind it to other types will panic.
+//
+// eg: `--body @payload.json` read value from the file, `--token -` read value from stdin.
+func WithValueFromFile() CliOptFn {
+	return func(opt *CliOpt) { opt.ValueFromFile = true }
+}
+
 // CliOpt define for a flag option
 type CliOpt struct {
 	// go flag value
@@ -649,6 +753,12 @@ type CliOpt struct {
 	Name, Desc string
 	// Shorts shorthand/alias names. eg: ["o", "a"]
 	Shorts []string
+	// Aliases long alias names of the option, they share the same value with the option.
+	//
+	// eg: Name: "dry-run", Aliases: ["dryrun"] - `--dry-run` = `--dryrun`
+	//
+	// TIP: multi-char names in "name,alias,s" syntax or Shorts will be moved to Aliases.
+	Aliases []string
 
 	// --- default value ---
 
@@ -682,12 +792,48 @@ type CliOpt struct {
 	//
 	// NOTE: Collector has higher priority than Question.
 	Question string
+	// ValueOptional the option value can be omitted. when given bare(eg: `--color`),
+	// the ImplicitValue will be used. the value can only be set by '=', eg: `--color=always`
+	//
+	// NOTE: the next arg will never be consumed as the option value, it is kept as an argument.
+	ValueOptional bool
+	// ImplicitValue the value for a ValueOptional option when it is given bare.
+	ImplicitValue string
+	// ValueFromFile allow read the option value from file or stdin. it is applied on Validate.
+	//
+	//  - "@path/to/file" read value from the file
+	//  - "-" read value from stdin
+	//  - "@@text" escape for a literal value "@text"
+	//
+	// NOTE: the size limited by MaxValueFileSize, trailing newlines will be trimmed.
+	// only for the string option, because the value is read after it is set to the flag.
+	ValueFromFile bool
+	// Deprecated mark the option is deprecated, the value is the message. eg: "use --output instead"
+	//
+	// A deprecated option still works, but it is hidden on help and completion,
+	// and a warning will be printed when it is used.
+	Deprecated string
+	// DeprecatedNames hidden, deprecated alias names(old names) of the option.
+	// using them still works, but a warning will be printed.
+	//
+	// eg: rename `--out` to `--output`, then DeprecatedNames: ["out"]
+	DeprecatedNames []string
 }
 
 // TakesValue reports whether the option consumes a value(ie. is not a bool flag).
 // useful for shell completion to decide value-completion vs command-completion.
 func (m *CliOpt) TakesValue() bool { return m.flagType != FlagTypeBool }
 
+// ConsumesNextArg reports whether the option takes the next arg as its value when
+// given without '='. bool and ValueOptional options never consume the next arg.
+func (m *CliOpt) ConsumesNextArg() bool { return m.TakesValue() && !m.ValueOptional }
+
+// IsDeprecated reports whether the option is deprecated.
+func (m *CliOpt) IsDeprecated() bool { return m.Deprecated != "" }
+
+// Visible reports whether the option should display on help and completion.
+func (m *CliOpt) Visible() bool { return !m.Hidden && m.Deprecated == "" }
+
 // TypeName get the flag type name. eg: bool, string, int, float, var, func
 // 公开已有的私有 flagType 字段, 供文档生成等场景读取选项类型。
 func (m *CliOpt) TypeName() string { return m.flagType }
@@ -719,11 +865,17 @@ func (m *CliOpt) WithOptFns(fns ...CliOptFn) *CliOpt {
 
 // init and check current option
 func (m *CliOpt) initCheck() string {
-	// feat: support add shorts by option name. eg: "name,n"
+	// feat: support add shorts and long aliases by option name. eg: "name,n", "dry-run,dryrun,d"
 	if strings.ContainsRune(m.Name, shortSepRune) {
 		ss := strings.Split(m.Name, shortSepChar)
 		m.Name = ss[0]
-		m.Shorts = append(m.Shorts, ss[1:]...)
+		for _, name := range cflag.FilterNames(ss[1:]) {
+			if len(name) > 1 {
+				m.Aliases = append(m.Aliases, name)
+			} else {
+				m.Shorts = append(m.Shorts, name)
+			}
+		}
 	}
 
 	// feat: support set default value from ENV.eg: "${DB_USERNAME}"
@@ -757,6 +909,9 @@ func (m *CliOpt) initCheck() string {
 	if len(m.Shorts) > 0 {
 		m.Shorts = cflag.FilterNames(m.Shorts)
 	}
+	if len(m.Aliases) > 0 {
+		m.Aliases = cflag.FilterNames(m.Aliases)
+	}
 	return m.goodName()
 }
 
@@ -789,7 +944,15 @@ func (m *CliOpt) ShortsString(sep ...string) string {
 
 // HelpName for show help
 func (m *CliOpt) HelpName() string {
-	return cflag.AddPrefixes(m.Name, m.Shorts)
+	return m.appendAliases(cflag.AddPrefixes(m.Name, m.Shorts))
+}
+
+// append long alias names to the help name. eg: "--dry-run, -d" -> "--dry-run, -d, --dryrun"
+func (m *CliOpt) appendAliases(helpName string) string {
+	for _, alias := range m.Aliases {
+		helpName += ", " + cflag.AddPrefix(alias)
+	}
+	return helpName
 }
 
 func (m *CliOpt) helpNameLen() int { return len(m.HelpName()) }
@@ -808,7 +971,18 @@ func (m *CliOpt) valIsEmpty(val string) bool {
 }
 
 // Validate the binding value after parsed
-func (m *CliOpt) Validate(val string) error {
+func (m *CliOpt) Validate(val string) error { return m.validate(val, nil, nil) }
+
+// validate the value, in and out for the Question prompt and read the value "-". see CliOpts.SetIO
+func (m *CliOpt) validate(val string, in io.Reader, out io.Writer) error {
+	// feat: read value from file or stdin. eg: "@payload.json", "-"
+	if m.ValueFromFile && m.flag != nil {
+		var err error
+		if val, err = m.loadFileValue(val, in); err != nil {
+			return err
+		}
+	}
+
 	valEmpty := m.valIsEmpty(val)
 
 	// feat: call custom value collector OR built-in question collector on empty value
@@ -816,7 +990,7 @@ func (m *CliOpt) Validate(val string) error {
 		collector := m.Collector
 		// build a built-in default collector based on Question
 		if collector == nil && m.Question != "" {
-			collector = func() (string, error) { return cliutil.ReadLine(m.Question) }
+			collector = func() (string, error) { return readAnswer(m.Question, in, out) }
 		}
 		if collector != nil {
 			valNew, err := collector()
@@ -835,7 +1009,7 @@ func (m *CliOpt) Validate(val string) error {
 
 	// check required
 	if m.Required && valEmpty {
-		return fmt.Errorf("option '%s' is required", m.Name)
+		return errors.New(gi18n.T("err.optRequired", m.Name))
 	}
 
 	if m.flag != nil {
@@ -848,7 +1022,7 @@ func (m *CliOpt) Validate(val string) error {
 	// call user custom value validator
 	if m.Validator != nil {
 		if err := m.Validator(val); err != nil {
-			return fmt.Errorf("option '%s': %s", m.Name, err.Error())
+			return errors.New(gi18n.T("err.optInvalid", m.Name, err.Error()))
 		}
 	}
 
@@ -859,6 +1033,33 @@ func (m *CliOpt) Validate(val string) error {
 	return nil
 }
 
+// loadFileValue read the option value from file or stdin(in, default is cliutil.Input), and set it to the flag value.
+func (m *CliOpt) loadFileValue(val string, in io.Reader) (string, error) {
+	var err error
+	var newVal string
+	switch {
+	case val == "-":
+		if in == nil {
+			in = cliutil.Input
+		}
+		newVal, err = readValueFrom("", in)
+	case strings.HasPrefix(val, "@@"):
+		newVal = val[1:]
+	case len(val) > 1 && val[0] == '@':
+		newVal, err = readValueFrom(val[1:], nil)
+	default:
+		return val, nil
+	}
+
+	if err == nil {
+		err = m.flag.Value.Set(newVal)
+	}
+	if err != nil {
+		return "", errors.New(gi18n.T("err.optInvalid", m.Name, err.Error()))
+	}
+	return newVal, nil
+}
+
 // Flag get raw flag.Flag
 func (m *CliOpt) Flag() *Flag { return m.flag }
 
@@ -893,6 +1094,20 @@ func (m *CliOpt) DefaultText() string {
 	return m.DValue().String()
 }
 
+// EnvVar returns the ENV var name of the default value. eg: "${DB_USER | root}" -> "DB_USER"
+func (m *CliOpt) EnvVar() string {
+	if m.defEnvVar == "" {
+		return ""
+	}
+
+	s := strings.TrimPrefix(m.defEnvVar, "$")
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	if i := strings.IndexByte(s, '|'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
 func (m *CliOpt) defaultPlaceholder(realDefVal any) string {
 	// env value, show env name
 	if m.defEnvVar != "" {
@@ -3,9 +3,11 @@ package gflag_test
 import (
 	"errors"
 	"io"
+	"os"
 	"strings"
 	"testing"
 
+	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3/gflag"
 	"github.com/gookit/goutil/cliutil"
 	"github.com/gookit/goutil/x/assert"
@@ -189,3 +191,284 @@ func TestCliOpt_TakesValue(t *testing.T) {
 	assert.True(t, fo.Opt("str").TakesValue())
 	assert.False(t, fo.Opt("bl").TakesValue())
 }
+
+func TestCliOpt_WithOptionalValue(t *testing.T) {
+	newFs := func(color, log *string) *gflag.Flags {
+		fs := gflag.New("test")
+		fs.StrOpt2(color, "color,c", "the color mode", gflag.WithOptionalValue("auto"))
+		fs.StrOpt2(log, "log", "the log file", gflag.WithOptionalValue("app.log"))
+		return fs
+	}
+
+	t.Run("bare use implicit value", func(t *testing.T) {
+		var color, log string
+		fs := newFs(&color, &log)
+		err := fs.Parse([]string{"--color", "arg0", "-c"})
+		assert.NoErr(t, err)
+		assert.Eq(t, "auto", color)
+		// next arg is not swallowed
+		assert.Eq(t, []string{"arg0"}, fs.RawArgs())
+		assert.False(t, fs.Opt("color").ConsumesNextArg())
+	})
+
+	t.Run("value set by equal sign", func(t *testing.T) {
+		var color, log string
+		fs := newFs(&color, &log)
+		err := fs.Parse([]string{"arg0", "--color=always", "--log=/tmp/a.log", "arg1"})
+		assert.NoErr(t, err)
+		assert.Eq(t, "always", color)
+		assert.Eq(t, "/tmp/a.log", log)
+		assert.Eq(t, []string{"arg0", "arg1"}, fs.RawArgs())
+	})
+
+	t.Run("not given keep default", func(t *testing.T) {
+		var color, log string
+		fs := newFs(&color, &log)
+		err := fs.Parse([]string{"arg0"})
+		assert.NoErr(t, err)
+		assert.Eq(t, "", color)
+		assert.Eq(t, "", log)
+	})
+
+	t.Run("help hint", func(t *testing.T) {
+		var color, log string
+		fs := newFs(&color, &log)
+		help := fs.BuildOptsHelp()
+		assert.StrContains(t, help, `string[="auto"]`)
+		assert.StrContains(t, help, `string[="app.log"]`)
+	})
+}
+
+func TestCliOpt_WithDeprecated(t *testing.T) {
+	var name, dir string
+	var used [][2]string
+
+	fs := gflag.New("test")
+	fs.StrOpt2(&name, "name", "the name", gflag.WithDeprecated("use '--user' instead"))
+	fs.StrOpt2(&dir, "work-dir", "the work dir", gflag.WithDeprecatedNames("workdir"))
+	fs.OnDeprecated(func(name, msg string) {
+		used = append(used, [2]string{name, msg})
+	})
+
+	assert.True(t, fs.Opt("name").IsDeprecated())
+	assert.False(t, fs.Opt("name").Visible())
+	assert.True(t, fs.Opt("work-dir").Visible())
+
+	err := fs.Parse([]string{"--name", "tom", "--workdir", "/tmp", "arg0"})
+	assert.NoErr(t, err)
+	assert.Eq(t, "tom", name)
+	assert.Eq(t, "/tmp", dir)
+	assert.Eq(t, []string{"arg0"}, fs.RawArgs())
+	assert.Len(t, used, 2)
+	assert.Eq(t, [2]string{"name", "use '--user' instead"}, used[0])
+	assert.Eq(t, [2]string{"workdir", "use '--work-dir' instead"}, used[1])
+
+	// new name not report
+	used = nil
+	fs2 := gflag.New("test")
+	fs2.StrOpt2(&dir, "work-dir", "the work dir", gflag.WithDeprecatedNames("workdir"))
+	fs2.OnDeprecated(func(name, msg string) { used = append(used, [2]string{name, msg}) })
+	assert.NoErr(t, fs2.Parse([]string{"--work-dir", "/home"}))
+	assert.Eq(t, "/home", dir)
+	assert.Empty(t, used)
+
+	// deprecated option is hidden on help
+	help := fs.BuildOptsHelp()
+	assert.NotContains(t, help, "--name")
+	assert.StrContains(t, help, "--work-dir")
+
+	// deprecated name conflict with exists option
+	assert.Panics(t, func() {
+		fs.StrOpt2(&dir, "other", "desc", gflag.WithDeprecatedNames("name"))
+	})
+}
+
+func TestCliOpt_WithAliases(t *testing.T) {
+	var dryRun bool
+	var color string
+
+	fs := gflag.New("test")
+	fs.BoolOpt2(&dryRun, "dry-run,dryrun,d", "run without changes")
+	fs.StrOpt2(&color, "color", "the color mode", gflag.WithAliases("colour"))
+
+	opt := fs.Opt("dry-run")
+	assert.Eq(t, []string{"d"}, opt.Shorts)
+	assert.Eq(t, []string{"dryrun"}, opt.Aliases)
+	assert.Eq(t, "--dry-run, -d, --dryrun", opt.HelpName())
+	assert.Eq(t, []string{"colour"}, fs.Opt("color").Aliases)
+
+	err := fs.Parse([]string{"arg0", "--dryrun", "--colour", "never", "arg1"})
+	assert.NoErr(t, err)
+	assert.True(t, dryRun)
+	assert.Eq(t, "never", color)
+	assert.Eq(t, []string{"arg0", "arg1"}, fs.RawArgs())
+
+	// alias is one option with one value
+	assert.NoErr(t, fs.Parse([]string{"--colour=auto", "--color", "always"}))
+	assert.Eq(t, "always", color)
+
+	help := fs.BuildOptsHelp()
+	assert.StrContains(t, help, "--colour")
+	assert.StrContains(t, help, "--dryrun")
+
+	// the multi-char names in the Shorts are kept as shorts, only the name syntax makes aliases
+	var name string
+	fs.StrVar(&name, &gflag.CliOpt{Name: "name", Shorts: []string{"nm"}, Desc: "the name"})
+	assert.Eq(t, []string{"nm"}, fs.Opt("name").Shorts)
+	assert.Empty(t, fs.Opt("name").Aliases)
+	assert.Eq(t, []string{"nm"}, fs.ShortNames("name"))
+	assert.Eq(t, "--name, --nm", fs.Opt("name").HelpName()) // same as before, rendered by cflag.AddPrefixes
+
+	// alias name conflict
+	assert.Panics(t, func() {
+		fs.StrOpt2(&color, "other", "desc", gflag.WithAliases("color"))
+	})
+	assert.Panics(t, func() {
+		fs.StrOpt2(&color, "dryrun", "desc")
+	})
+}
+
+func TestCliOpt_WithValueFromFile(t *testing.T) {
+	oldIn := cliutil.Input
+	defer func() { cliutil.Input = oldIn }()
+
+	file := t.TempDir() + "/payload.json"
+	assert.NoErr(t, os.WriteFile(file, []byte(`{"name": "tom"}`+"\n"), 0644))
+
+	newFs := func(body, token *string) *gflag.Flags {
+		fs := gflag.New("test")
+		fs.StrOpt2(body, "body", "the body", gflag.WithValueFromFile())
+		fs.StrOpt2(token, "token", "the token", gflag.WithValueFromFile(), gflag.WithRequired())
+		return fs
+	}
+
+	t.Run("from file and stdin", func(t *testing.T) {
+		cliutil.Input = strings.NewReader("secret\n")
+		var body, token string
+		fs := newFs(&body, &token)
+		assert.NoErr(t, fs.Parse([]string{"--body", "@" + file, "--token", "-", "arg0"}))
+		assert.Eq(t, `{"name": "tom"}`, body)
+		assert.Eq(t, "secret", token)
+		assert.Eq(t, []string{"arg0"}, fs.RawArgs())
+	})
+
+	t.Run("escape and plain value", func(t *testing.T) {
+		var body, token string
+		fs := newFs(&body, &token)
+		assert.NoErr(t, fs.Parse([]string{"--body", "@@tom", "--token", "abc"}))
+		assert.Eq(t, "@tom", body)
+		assert.Eq(t, "abc", token)
+	})
+
+	t.Run("error cases", func(t *testing.T) {
+		var body, token string
+		fs := newFs(&body, &token)
+		err := fs.Parse([]string{"--body", "@not-exist.json", "--token", "abc"})
+		assert.ErrSubMsg(t, err, "option 'body': read value file failed")
+
+		// empty stdin for a required option
+		cliutil.Input = strings.NewReader("")
+		body = ""
+		fs = newFs(&body, &token)
+		err = fs.Parse([]string{"--token", "-"})
+		assert.ErrMsg(t, err, "option 'token' is required")
+
+		oldMax := gflag.MaxValueFileSize
+		defer func() { gflag.MaxValueFileSize = oldMax }()
+		gflag.MaxValueFileSize = 5
+		fs = newFs(&body, &token)
+		err = fs.Parse([]string{"--body", "@" + file, "--token", "abc"})
+		assert.ErrSubMsg(t, err, "exceeds the max size 5 bytes")
+	})
+
+	t.Run("fileval struct tag", func(t *testing.T) {
+		opts := struct {
+			Body string `flag:"desc=the body" fileval:"true"`
+		}{}
+		fs := gflag.New("test")
+		assert.NoErr(t, fs.FromStruct(&opts))
+		assert.True(t, fs.Opt("body").ValueFromFile)
+		assert.NoErr(t, fs.Parse([]string{"--body", "@" + file}))
+		assert.Eq(t, `{"name": "tom"}`, opts.Body)
+	})
+
+	// the value is read after set, so only the string option is allowed
+	t.Run("non-string option", func(t *testing.T) {
+		var num int
+		assert.PanicsMsg(t, func() {
+			gflag.New("test").IntOpt2(&num, "num", "the num", gflag.WithValueFromFile())
+		}, "gflag: option 'num': ValueFromFile only support the string option, got type int")
+
+		opts := struct {
+			Num int `flag:"desc=the num" fileval:"true"`
+		}{}
+		err := gflag.New("test").FromStruct(&opts)
+		assert.ErrMsg(t, err, "field: Num - fileval only support the string type, got int")
+	})
+}
+
+func TestWrapText(t *testing.T) {
+	is := assert.New(t)
+
+	is.Eq(5, gflag.TextWidth("<info>hello</>"))
+	is.Eq(4, gflag.TextWidth("\x1b[0;32m你好\x1b[0m"))
+
+	// not wrap
+	is.Eq("short text", gflag.WrapText("short text", 10, 80))
+	is.Eq("line1\n    line2", gflag.WrapText("line1\nline2", 4, 0))
+
+	// wrap with hanging indent
+	s := gflag.WrapText("the quick brown fox jumps over the lazy dog", 4, 28)
+	is.Eq("the quick brown fox\n    jumps over the lazy dog", s)
+
+	// color tags are not counted
+	s = gflag.WrapText("the <info>quick</> brown fox <red>jumps</> over the lazy dog", 4, 28)
+	is.Eq("the <info>quick</> brown fox\n    <red>jumps</> over the lazy dog", s)
+
+	// wide chars can be broken at any char
+	s = gflag.WrapText("这是一个很长的中文描述信息用于测试自动换行", 2, 24)
+	is.Eq("这是一个很长的中文描述\n  信息用于测试自动换行", s)
+	for _, line := range strings.Split(s, "\n") {
+		is.True(gflag.TextWidth(line) <= 24)
+	}
+
+	// keep the newlines
+	s = gflag.WrapText("first line\nthe quick brown fox jumps over", 2, 24)
+	is.Eq("first line\n  the quick brown fox\n  jumps over", s)
+}
+
+func TestParser_HelpWidth(t *testing.T) {
+	is := assert.New(t)
+
+	var name, dir string
+	fs := gflag.New("test").WithConfigFn(gflag.WithHelpWidth(50))
+	fs.StrOpt(&name, "name", "n", "", "the user name for login, it is used to identify the user on the server")
+	fs.StrOpt(&dir, "dir", "", "", "the work dir")
+	fs.AddArg("files", "the input files for process, can be multi files or directories", true, true)
+
+	is.Eq(50, fs.HelpWidth())
+	help := color.ClearTag(fs.BuildOptsHelp())
+	lines := strings.Split(strings.TrimRight(help, "\n"), "\n")
+	is.Len(lines, 4)
+	for _, line := range lines {
+		is.True(gflag.TextWidth(line) <= 50, line)
+	}
+	// hanging indent aligned to the description column
+	descCol := strings.Index(lines[1], "The user name")
+	is.Eq(strings.Repeat(" ", descCol), lines[2][:descCol])
+
+	args := color.ClearTag(fs.BuildArgsHelp())
+	is.Eq("  files...     *The input files for process, can\n               be multi files or directories\n", args)
+
+	t.Run("disable wrap", func(t *testing.T) {
+		fs.WithConfigFn(gflag.WithHelpWidth(-1))
+		is.Eq(0, fs.HelpWidth())
+		is.Len(strings.Split(strings.TrimRight(fs.BuildOptsHelp(), "\n"), "\n"), 2)
+	})
+
+	t.Run("env COLUMNS", func(t *testing.T) {
+		t.Setenv("COLUMNS", "66")
+		is.Eq(66, gflag.ResolveHelpWidth(0))
+		is.Eq(30, gflag.ResolveHelpWidth(30))
+	})
+}
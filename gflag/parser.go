@@ -12,6 +12,7 @@ import (
 
 	"github.com/gookit/color"
 	"github.com/gookit/color/colorp"
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/goutil"
 	"github.com/gookit/goutil/cflag"
 	"github.com/gookit/goutil/maputil"
@@ -49,6 +50,10 @@ type Flags struct {
 	// reorderStop optional predicate for args reorder. when it returns true for a
 	// token, reordering stops at that token (used to not cross a sub-command).
 	reorderStop func(name string) bool
+	// deprecatedFn handler on a deprecated option or alias name is used. see OnDeprecated
+	deprecatedFn func(name, msg string)
+	// warned deprecated names by the default deprecated handler
+	warned map[string]bool
 }
 
 func newDefaultFlagConfig() *Config {
@@ -145,6 +150,31 @@ func (p *Parser) SetHandle(fn HandleFunc) *Flags {
 // boundary, so only the final executed command's args are reordered.
 func (p *Parser) SetReorderStop(fn func(name string) bool) { p.reorderStop = fn }
 
+// OnDeprecated set the handler on a deprecated option or alias name is used.
+//
+// - name: the used option name or alias name
+// - msg: the deprecated message. eg: "use '--output' instead"
+//
+// Default will print a warning message to stderr, only once for each name.
+func (p *Parser) OnDeprecated(fn func(name, msg string)) { p.deprecatedFn = fn }
+
+// default handler for deprecated option usage: print warning to stderr(see SetIO) once for each name.
+func (p *Parser) warnDeprecated(name, msg string) {
+	if p.warned[name] {
+		return
+	}
+
+	if p.warned == nil {
+		p.warned = make(map[string]bool)
+	}
+	p.warned[name] = true
+	errOut := p.errOut
+	if errOut == nil {
+		errOut = os.Stderr
+	}
+	color.Fprintf(errOut, "<yellow>WARNING</>: option '%s' is deprecated, %s\n", cflag.AddPrefix(name), msg)
+}
+
 /***********************************************************************
  * Flags:
  * - parse input flags
@@ -172,7 +202,7 @@ func (p *Parser) Run(args []string) {
 			color.Infoln(p.Desc)
 		}
 
-		color.Comment.Println("Usage:")
+		color.Comment.Println(gi18n.T("help.usage") + ":")
 		colorp.Cyanln(" ", binFile, "[--Options ...] [Arguments ...]\n")
 		p.PrintHelpPanel()
 	})
@@ -180,14 +210,14 @@ func (p *Parser) Run(args []string) {
 	// do parsing options
 	if err := p.Parse(waitArgs); err != nil {
 		if err != flag.ErrHelp {
-			color.Errorf("Parse options error: %s\n", err.Error())
+			color.Errorln(gi18n.T("err.parseOptions", err.Error()))
 		}
 		return // ignore help error
 	}
 
 	// parsing named arguments.
 	if err := p.ParseArgs(p.fSet.Args()); err != nil {
-		color.Errorf("Parse arguments error: %s\n", err.Error())
+		color.Errorln(gi18n.T("err.parseArguments", err.Error()))
 		return
 	}
 
@@ -231,6 +261,13 @@ func (p *Parser) Parse(args []string) (err error) {
 		args = rearrangeArgs(args, p.fSet, p.reorderStop)
 	}
 
+	// warn on deprecated option or alias name is used
+	if p.deprecatedFn != nil {
+		p.fSet.onDeprecated = p.deprecatedFn
+	} else {
+		p.fSet.onDeprecated = p.warnDeprecated
+	}
+
 	// do parsing options
 	if err = p.fSet.Parse(args); err != nil {
 		return err
@@ -294,6 +331,11 @@ func (p *Parser) MustFromStruct(ptr any, ruleType ...uint8) {
 //	}
 //	opt := &UserCmdOpts{}
 //	p.FromStruct(opt, gflag.TagRuleSimple)
+//
+// ## Extra tags
+//
+//   - `enum:"a,b,c"` the allowed values of the option
+//   - `fileval:"true"` allow read value from @file or '-'(stdin), see CliOpt.ValueFromFile
 func (p *Parser) FromStruct(ptr any, ruleType ...uint8) (err error) {
 	v := reflect.ValueOf(ptr)
 	if v.Kind() != reflect.Ptr {
@@ -435,6 +477,14 @@ func (p *Parser) fromStructValue(v reflect.Value, tagName string) error {
 			}
 		}
 
+		// fileval:"true" -> allow read value from @file or '-'(stdin). see CliOpt.ValueFromFile
+		if fileVal := sf.Tag.Get("fileval"); fileVal != "" {
+			opt.ValueFromFile = strutil.QuietBool(fileVal)
+			if opt.ValueFromFile && ft.Kind() != reflect.String {
+				return fmt.Errorf("field: %s - fileval only support the string type, got %s", name, ft.String())
+			}
+		}
+
 		// field is implements flag.Value
 		if ft.Implements(flagValueType) {
 			p.Var(fv.Interface().(flag.Value), opt)
@@ -7,10 +7,11 @@ import "strings"
 //
 // A bool option does not consume a following value token; a value-taking option
 // does. used by rearrangeArgs to keep an option grouped with its value.
+//
+// NOTE: an optional-value option is reported as bool-like, it never consumes
+// the following token(its value can only be given by '=').
 func (f *FlagSet) optMeta(name string) (known, isBool bool) {
-	if full, ok := f.shorts[name]; ok {
-		name = full
-	}
+	name = f.resolveName(name)
 
 	flg, ok := f.formal[name]
 	if !ok {
@@ -19,7 +20,7 @@ func (f *FlagSet) optMeta(name string) (known, isBool bool) {
 	if fv, ok := flg.Value.(boolFlag); ok && fv.IsBoolFlag() {
 		return true, true
 	}
-	return true, false
+	return true, f.isOptional(name)
 }
 
 // looksLikeOption reports whether s is an option token (-x or --xxx).
@@ -46,7 +47,7 @@ func looksLikeOption(s string) bool {
 //     keeping their original relative order;
 //   - positional arguments keep their original relative order, after options;
 //   - a known value-taking option groups the next token as its value (-name tom);
-//   - bool options and the `--opt=val` form do not consume the next token;
+//   - bool options, optional-value options and the `--opt=val` form do not consume the next token;
 //   - negative-number-like tokens and a lone "-" are arguments (see looksLikeOption);
 //   - "--" terminates reordering: it and everything after are kept verbatim;
 //   - when stopAt(token) is true (token is a known sub-command name), reordering
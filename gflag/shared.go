@@ -24,7 +24,7 @@ func (p *Parser) InheritOptsFrom(src *Parser, category ...string) {
 
 	for name, opt := range src.Opts() {
 		// 子命令局部同名选项优先, 跳过继承
-		if p.HasOption(name) {
+		if p.HasOption(name) || p.isAliasName(name) {
 			continue
 		}
 		// 选项未绑定 flag.Value, 无法复用, 跳过
@@ -32,7 +32,7 @@ func (p *Parser) InheritOptsFrom(src *Parser, category ...string) {
 			continue
 		}
 		// 短名冲突检测: 任一短名已被 p 用作选项名或短名时, 跳过该选项, 避免 Var 内部 panic
-		if p.shortsConflict(opt.Shorts) {
+		if p.shortsConflict(opt.Shorts) || p.shortsConflict(opt.Aliases) || p.shortsConflict(opt.DeprecatedNames) {
 			continue
 		}
 
@@ -40,19 +40,27 @@ func (p *Parser) InheritOptsFrom(src *Parser, category ...string) {
 		p.Var(opt.flag.Value, &CliOpt{
 			Name:      opt.Name,
 			Shorts:    opt.Shorts,
+			Aliases:   opt.Aliases,
 			Desc:      opt.Desc,
 			Required:  opt.Required,
 			Validator: opt.Validator,
 			Choices:   opt.Choices,
-			Category:  cat, // 继承选项归入指定 help 分组
+			// optional-value setting must be kept, it affects parse and reorder
+			ValueOptional: opt.ValueOptional,
+			ImplicitValue: opt.ImplicitValue,
+			ValueFromFile: opt.ValueFromFile,
+			// deprecated state and old names, keep them working on sub commands
+			Deprecated:      opt.Deprecated,
+			DeprecatedNames: opt.DeprecatedNames,
+			Category:        cat, // 继承选项归入指定 help 分组
 		})
 	}
 }
 
-// shortsConflict 检测给定的短名集合中是否有任意一个已被 p 用作选项名或已注册短名。
+// shortsConflict 检测给定的短名(或别名)集合中是否有任意一个已被 p 用作选项名、短名或别名。
 func (p *Parser) shortsConflict(shorts []string) bool {
 	for _, short := range shorts {
-		if p.HasOption(short) || p.IsShortName(short) {
+		if p.HasOption(short) || p.IsShortName(short) || p.isAliasName(short) {
 			return true
 		}
 	}
@@ -1,12 +1,20 @@
 package gflag
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
+	"github.com/gookit/gcli/v3/gi18n"
+	"github.com/gookit/gcli/v3/internal/helper"
 	"github.com/gookit/goutil/arrutil"
 	"github.com/gookit/goutil/cflag"
+	"github.com/gookit/goutil/cliutil"
 	"github.com/gookit/goutil/comdef"
 	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/x/ccolor"
 )
 
 func sepStr(seps []string) string {
@@ -35,7 +43,7 @@ func enumValidator(choices []string) func(string) error {
 		if val == "" || arrutil.StringsHas(choices, val) {
 			return nil
 		}
-		return fmt.Errorf("value %q is not in the allowed list: %v", val, choices)
+		return errors.New(gi18n.T("err.notInChoices", val, choices))
 	}
 }
 
@@ -144,3 +152,44 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 	}
 	return
 }
+
+// readValueFrom read option value from the file, or the reader(stdin) if file is empty.
+// the size is limited by MaxValueFileSize, and trailing newlines are trimmed.
+func readValueFrom(file string, in io.Reader) (string, error) {
+	src := "stdin"
+	if file != "" {
+		fh, err := os.Open(file)
+		if err != nil {
+			return "", fmt.Errorf("read value file failed: %s", err.Error())
+		}
+		defer fh.Close()
+		in, src = fh, "file '"+file+"'"
+	}
+
+	bs, err := io.ReadAll(io.LimitReader(in, MaxValueFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("read value from %s failed: %s", src, err.Error())
+	}
+	if int64(len(bs)) > MaxValueFileSize {
+		return "", fmt.Errorf("value from %s exceeds the max size %d bytes", src, MaxValueFileSize)
+	}
+
+	// trim trailing newlines. eg: `echo $TOKEN | app --token -`
+	return strings.TrimRight(string(bs), "\r\n"), nil
+}
+
+// readAnswer print the question and read an answer line. in, out is nil for cliutil.Input, cliutil.Output
+func readAnswer(question string, in io.Reader, out io.Writer) (string, error) {
+	if in == nil && out == nil {
+		return cliutil.ReadLine(question)
+	}
+
+	if in == nil {
+		in = cliutil.Input
+	}
+	if out == nil {
+		out = cliutil.Output
+	}
+	ccolor.Info.Fprint(out, question)
+	return helper.ReadLine(in)
+}
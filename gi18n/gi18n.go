@@ -0,0 +1,199 @@
+// Package gi18n provide the message catalogs for the built-in help and error messages of gcli.
+//
+// The locale is detected from the env LC_ALL, LC_MESSAGES, LANG on init, default is "en".
+//
+// Usage:
+//
+//	gi18n.SetLocale("zh-CN")
+//	// add or override messages
+//	gi18n.AddCatalog("zh-CN", gi18n.Catalog{"help.usage": "用法"})
+//
+//	fmt.Println(gi18n.T("help.usage"))
+package gi18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale the fallback locale, all message keys must exist in it.
+const DefaultLocale = "en"
+
+// Catalog the messages of a locale. key is message key, value is the message or format.
+type Catalog map[string]string
+
+var (
+	mu     sync.RWMutex
+	locale = DefaultLocale
+	// locale => messages
+	catalogs = map[string]Catalog{
+		DefaultLocale: enCatalog,
+		"zh-CN":       zhCNCatalog,
+	}
+)
+
+func init() {
+	locale = DetectLocale()
+}
+
+// DetectLocale detect the locale from the env LC_ALL, LC_MESSAGES, LANG.
+//
+// returns DefaultLocale on not set, or it is "C", "POSIX".
+func DetectLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if val := os.Getenv(name); val != "" {
+			return Normalize(val)
+		}
+	}
+	return DefaultLocale
+}
+
+// Normalize the locale name. eg: "zh_CN.UTF-8" -> "zh-CN", "C" -> "en"
+func Normalize(name string) string {
+	// strip the codeset and modifier. eg: ".UTF-8", "@euro"
+	if pos := strings.IndexAny(name, ".@"); pos >= 0 {
+		name = name[:pos]
+	}
+
+	name = strings.TrimSpace(strings.ReplaceAll(name, "_", "-"))
+	if name == "" || name == "C" || name == "POSIX" {
+		return DefaultLocale
+	}
+
+	lang, region, ok := strings.Cut(name, "-")
+	if !ok {
+		return strings.ToLower(lang)
+	}
+	return strings.ToLower(lang) + "-" + strings.ToUpper(region)
+}
+
+// SetLocale set the current locale. eg: "zh-CN", "zh_CN.UTF-8"
+//
+// will detect it from the env on name is empty. see DetectLocale
+func SetLocale(name string) {
+	if name == "" {
+		name = DetectLocale()
+	}
+
+	mu.Lock()
+	locale = Normalize(name)
+	mu.Unlock()
+}
+
+// Locale get the current locale
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// Locales get all locale names that have the catalog.
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	ls := make([]string, 0, len(catalogs))
+	for name := range catalogs {
+		ls = append(ls, name)
+	}
+	return ls
+}
+
+// AddCatalog add a catalog for the locale. will merge to the exists messages.
+func AddCatalog(name string, c Catalog) {
+	name = Normalize(name)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// NOTE: copy the built-in catalog, avoid modify the package level vars.
+	old := catalogs[name]
+	merged := make(Catalog, len(old)+len(c))
+	for k, v := range old {
+		merged[k] = v
+	}
+	for k, v := range c {
+		merged[k] = v
+	}
+	catalogs[name] = merged
+}
+
+// Has check the message key exists in the current locale, or its language.
+func Has(key string) bool {
+	_, ok := lookup(key)
+	return ok
+}
+
+// T translate the message by key for the current locale. will format it on args is not empty.
+//
+// fallback order: "zh-CN" -> "zh" -> DefaultLocale -> the key.
+func T(key string, args ...any) string {
+	msg, ok := lookup(key)
+	if !ok {
+		msg = DefaultMessage(key)
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// DefaultMessage get the message of the DefaultLocale. returns the key on not found.
+func DefaultMessage(key string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if msg, ok := catalogs[DefaultLocale][key]; ok {
+		return msg
+	}
+	return key
+}
+
+func lookup(key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, name := range candidates(locale) {
+		if msg, ok := catalogs[name][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// candidates get the locale and its language. eg: "zh-CN" -> ["zh-CN", "zh"]
+func candidates(name string) []string {
+	if lang, _, ok := strings.Cut(name, "-"); ok {
+		return []string{name, lang}
+	}
+	return []string{name}
+}
+
+// Pick the value for the current locale from the map keyed by locale.
+//
+// fallback order: "zh-CN" -> "zh". returns false on not found.
+//
+// Usage:
+//
+//	desc, ok := gi18n.Pick(map[string]string{"zh-CN": "描述", "en": "description"})
+func Pick[T any](m map[string]T) (val T, ok bool) {
+	if len(m) == 0 {
+		return
+	}
+
+	for _, name := range candidates(Locale()) {
+		if val, ok = m[name]; ok {
+			return
+		}
+		// key is not normalized. eg: "zh_CN"
+		for key, v := range m {
+			if Normalize(key) == name {
+				return v, true
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package gi18n_test
+
+import (
+	"testing"
+
+	"github.com/gookit/gcli/v3/gi18n"
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"":            "en",
+		"C":           "en",
+		"POSIX":       "en",
+		"C.UTF-8":     "en",
+		"zh_CN.UTF-8": "zh-CN",
+		"zh-cn":       "zh-CN",
+		"de_DE@euro":  "de-DE",
+		"FR":          "fr",
+	}
+	for in, want := range tests {
+		assert.Eq(t, want, gi18n.Normalize(in), "input: "+in)
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "zh_CN.UTF-8")
+	assert.Eq(t, "zh-CN", gi18n.DetectLocale())
+
+	t.Setenv("LC_ALL", "C")
+	assert.Eq(t, "en", gi18n.DetectLocale())
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "")
+	assert.Eq(t, gi18n.DefaultLocale, gi18n.DetectLocale())
+}
+
+func TestT(t *testing.T) {
+	defer gi18n.SetLocale(gi18n.Locale())
+
+	gi18n.SetLocale("en")
+	assert.Eq(t, "Usage", gi18n.T("help.usage"))
+	assert.Eq(t, "option 'name' is required", gi18n.T("err.optRequired", "name"))
+	assert.Eq(t, "not.exists.key", gi18n.T("not.exists.key"))
+
+	gi18n.SetLocale("zh_CN.UTF-8")
+	assert.Eq(t, "zh-CN", gi18n.Locale())
+	assert.Eq(t, "用法", gi18n.T("help.usage"))
+	assert.Eq(t, `选项 --age 的值 "abc" 无效: bad`, gi18n.T("err.optInvalidValue", "abc", "--age", "bad"))
+
+	// fallback to the default locale
+	gi18n.SetLocale("ja-JP")
+	assert.False(t, gi18n.Has("help.usage"))
+	assert.Eq(t, "Usage", gi18n.T("help.usage"))
+}
+
+func TestAddCatalog(t *testing.T) {
+	defer gi18n.SetLocale(gi18n.Locale())
+
+	gi18n.AddCatalog("fr", gi18n.Catalog{"help.usage": "Utilisation"})
+	assert.Contains(t, gi18n.Locales(), "fr")
+
+	// "fr-FR" fallback to the language "fr"
+	gi18n.SetLocale("fr_FR.UTF-8")
+	assert.True(t, gi18n.Has("help.usage"))
+	assert.Eq(t, "Utilisation", gi18n.T("help.usage"))
+	assert.Eq(t, "Options", gi18n.T("help.options"))
+
+	// override a built-in message, restore it after test
+	gi18n.SetLocale("zh-CN")
+	old := gi18n.T("help.usage")
+	t.Cleanup(func() { gi18n.AddCatalog("zh-CN", gi18n.Catalog{"help.usage": old}) })
+
+	gi18n.AddCatalog("zh-CN", gi18n.Catalog{"help.usage": "使用方式"})
+	assert.Eq(t, "使用方式", gi18n.T("help.usage"))
+	assert.Eq(t, "选项", gi18n.T("help.options"))
+	assert.Eq(t, "Usage", gi18n.DefaultMessage("help.usage"))
+}
+
+func TestPick(t *testing.T) {
+	defer gi18n.SetLocale(gi18n.Locale())
+
+	m := map[string]string{"zh": "中文", "de_DE": "Deutsch"}
+	gi18n.SetLocale("zh-TW")
+	val, ok := gi18n.Pick(m)
+	assert.True(t, ok)
+	assert.Eq(t, "中文", val)
+
+	gi18n.SetLocale("de-DE")
+	val, ok = gi18n.Pick(m)
+	assert.True(t, ok)
+	assert.Eq(t, "Deutsch", val)
+
+	gi18n.SetLocale("en")
+	_, ok = gi18n.Pick(m)
+	assert.False(t, ok)
+}
@@ -0,0 +1,119 @@
+package gi18n
+
+// enCatalog the built-in messages for DefaultLocale.
+//
+// TIP: the message can contain the color tags and help vars. eg: "<info>", "{$binName}"
+var enCatalog = Catalog{
+	// help section titles
+	"help.name":          "Name",
+	"help.alias":         "alias",
+	"help.usage":         "Usage",
+	"help.globalOptions": "Global Options",
+	"help.options":       "Options",
+	"help.arguments":     "Arguments",
+	"help.examples":      "Examples",
+	"help.help":          "Help",
+	"help.commands":      "Available Commands",
+	"help.subcommands":   "Subcommands",
+	"help.userAliases":   "User Aliases",
+	"help.plugins":       "Plugins",
+	"help.aliasFor":      "alias for '%s'",
+	"help.pluginFrom":    "plugin: %s",
+	"help.helpCommand":   "Display help information",
+	"help.moreInfo":      `Use "<cyan>%s COMMAND -h</>" for more information about a command.`,
+	"help.helpUsage":     "Display help message for application or command.",
+	"help.default":       "default",
+	"help.repeatable":    "repeatable",
+	"help.noDesc":        "No description",
+
+	// built-in option descriptions
+	"opt.help":          "Display the help information",
+	"opt.version":       "Display app version information",
+	"opt.genCompletion": "generate completion script for shell(bash/zsh/pwsh)",
+	"opt.noPager":       "Do not pipe the long output into a pager",
+	"opt.logFormat":     "The format of the diagnostic logs, allow: text, json",
+	"opt.logLevel":      "The log level, allow: quiet, error, warn, info, debug, crazy",
+	"opt.dryRun":        "Only print what would be done, do not make any changes",
+	"opt.yes":           "Automatic yes to the confirm prompts, for the non-interactive run",
+
+	// tips and suggestions
+	"tip.unknownCommand":  `unknown input command "<mga>%s</>"`,
+	"tip.maybeYouMean":    "Maybe you mean",
+	"tip.seeCommands":     "Use <cyan>%s --help</> to see available commands",
+	"tip.unknownHelpCmd":  "Unknown command name '%s'. Run '%s -h' see all commands",
+	"tip.tooManyHelpArgs": "Too many arguments given.\n\nUsage: %s help COMMAND",
+	"tip.wouldDo":         "<mga>[dry-run]</> would %s",
+	"tip.useYes":          "use --yes to confirm",
+
+	// parse and validate errors
+	"err.subcommandNotFound": "%s - subcommand %q is not found",
+	"err.optRequired":        "option '%s' is required",
+	"err.noInteractive":      "cannot confirm %q",
+	"err.optInvalid":         "option '%s': %s",
+	"err.optNotDefined":      "option provided but not defined: %s",
+	"err.optNeedsArgument":   "flag option needs an argument: %s",
+	"err.optInvalidValue":    "invalid value %q for option %s: %v",
+	"err.optInvalidBool":     "invalid boolean value %q for %s: %v",
+	"err.badFlagSyntax":      "bad flag syntax: %s",
+	"err.notInChoices":       "value %q is not in the allowed list: %v",
+	"err.argRequired":        "must set value for the argument: %s(position#%d)",
+	"err.tooManyArgs":        "entered too many arguments: %v",
+	"err.parseOptions":       "Parse options error: %s",
+	"err.parseArguments":     "Parse arguments error: %s",
+}
+
+// zhCNCatalog the built-in messages for "zh-CN"
+var zhCNCatalog = Catalog{
+	"help.name":          "名称",
+	"help.alias":         "别名",
+	"help.usage":         "用法",
+	"help.globalOptions": "全局选项",
+	"help.options":       "选项",
+	"help.arguments":     "参数",
+	"help.examples":      "示例",
+	"help.help":          "帮助",
+	"help.commands":      "可用命令",
+	"help.subcommands":   "子命令",
+	"help.userAliases":   "用户别名",
+	"help.plugins":       "插件",
+	"help.aliasFor":      "'%s' 的别名",
+	"help.pluginFrom":    "插件: %s",
+	"help.helpCommand":   "显示帮助信息",
+	"help.moreInfo":      `使用 "<cyan>%s COMMAND -h</>" 查看命令的详细信息。`,
+	"help.helpUsage":     "显示应用或命令的帮助信息。",
+	"help.default":       "默认",
+	"help.repeatable":    "可重复",
+	"help.noDesc":        "暂无描述",
+
+	"opt.help":          "显示帮助信息",
+	"opt.version":       "显示应用版本信息",
+	"opt.genCompletion": "生成 shell(bash/zsh/pwsh) 补全脚本",
+	"opt.noPager":       "不使用分页器显示长输出",
+	"opt.logFormat":     "诊断日志的格式, 可选: text, json",
+	"opt.logLevel":      "日志级别, 可选: quiet, error, warn, info, debug, crazy",
+	"opt.dryRun":        "只打印将会执行的操作, 不做任何更改",
+	"opt.yes":           "自动确认所有提示, 用于非交互式运行",
+
+	"tip.unknownCommand":  `未知的命令 "<mga>%s</>"`,
+	"tip.maybeYouMean":    "您是否想输入",
+	"tip.seeCommands":     "使用 <cyan>%s --help</> 查看可用命令",
+	"tip.unknownHelpCmd":  "未知的命令名称 '%s'。运行 '%s -h' 查看所有命令",
+	"tip.tooManyHelpArgs": "参数过多。\n\n用法: %s help COMMAND",
+	"tip.wouldDo":         "<mga>[dry-run]</> 将会 %s",
+	"tip.useYes":          "使用 --yes 进行确认",
+
+	"err.subcommandNotFound": "%s - 子命令 %q 不存在",
+	"err.optRequired":        "选项 '%s' 是必须的",
+	"err.noInteractive":      "无法确认 %q",
+	"err.optInvalid":         "选项 '%s': %s",
+	"err.optNotDefined":      "选项未定义: %s",
+	"err.optNeedsArgument":   "选项需要一个值: %s",
+	"err.optInvalidValue":    "选项 %[2]s 的值 %[1]q 无效: %[3]v",
+	"err.optInvalidBool":     "选项 %[2]s 的布尔值 %[1]q 无效: %[3]v",
+	"err.badFlagSyntax":      "错误的选项语法: %s",
+	"err.notInChoices":       "值 %q 不在允许的列表中: %v",
+	"err.argRequired":        "必须为参数设置值: %s(位置#%d)",
+	"err.tooManyArgs":        "输入的参数过多: %v",
+	"err.parseOptions":       "解析选项错误: %s",
+	"err.parseArguments":     "解析参数错误: %s",
+}
@@ -0,0 +1,17 @@
+package gi18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestCatalog_keys(t *testing.T) {
+	for key, msg := range zhCNCatalog {
+		enMsg, ok := enCatalog[key]
+		assert.True(t, ok, "key not in the en catalog: "+key)
+		assert.Eq(t, strings.Count(enMsg, "%"), strings.Count(msg, "%"), "format args not match: "+key)
+	}
+	assert.Len(t, zhCNCatalog, len(enCatalog))
+}
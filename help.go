@@ -2,12 +2,15 @@ package gcli
 
 import (
 	"fmt"
+	"io/fs"
 	"sort"
 	"strings"
 	"text/template"
 
 	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3/gevent"
+	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/gcli/v3/gi18n"
 	"github.com/gookit/gcli/v3/internal/helper"
 	"github.com/gookit/goutil/maputil"
 	"github.com/gookit/goutil/strutil"
@@ -20,6 +23,31 @@ type HelpConfig struct {
 	AfterCmdText string
 	// FooterText add help footer text on help end
 	FooterText string
+	// Width for wrap the descriptions of commands, options and arguments.
+	//
+	// 	0  - auto detect by the env COLUMNS or the terminal width. default
+	// 	>0 - wrap to the width
+	// 	<0 - disable wrap
+	Width int
+}
+
+// helpWidth resolve the width for wrap help text, and sync it to the flags parser if not set.
+func helpWidth(fs *gflag.Flags, width int) int {
+	if cfg := fs.ParserCfg(); cfg.HelpWidth == 0 {
+		cfg.HelpWidth = width
+	}
+	return gflag.ResolveHelpWidth(width)
+}
+
+// cmdListDesc render the command description on the commands list, wrap it with hanging indent.
+//
+// eg: "  <info>name</> Description message (alias: <green>n</>)"
+func cmdListDesc(c *Command, nameWidth, width int) string {
+	desc := c.HelpDesc()
+	if len(c.Aliases) > 0 {
+		desc += " (alias: <green>" + strings.Join(c.Aliases, ",") + "</>)"
+	}
+	return gflag.WrapText(desc, nameWidth+3, width)
 }
 
 // CmdGroup is a group of commands by category. used for render help.
@@ -69,81 +97,95 @@ func (b *base) CommandsByGroup(defaultTitle string) []*CmdGroup {
  * display app help
  *************************************************************/
 
+// helpCmdGroups get command groups for render app help.
+// will append the "User Aliases" and "Plugins" groups if exists.
+func (app *App) helpCmdGroups() []*CmdGroup {
+	groups := app.CommandsByGroup(gi18n.T("help.commands"))
+
+	// fake commands for render help
+	if names := app.userAliasNames(); len(names) > 0 {
+		cmds := make([]*Command, 0, len(names))
+		for _, name := range names {
+			cmds = append(cmds, &Command{Name: name, Desc: gi18n.T("help.aliasFor", app.userAliases[name])})
+		}
+		groups = append(groups, &CmdGroup{Name: "aliases", Title: gi18n.T("help.userAliases"), Cmds: cmds})
+	}
+
+	if ps := app.pluginsOf(""); len(ps) > 0 {
+		cmds := make([]*Command, 0, len(ps))
+		for _, p := range ps {
+			cmds = append(cmds, &Command{Name: p.Name, Desc: gi18n.T("help.pluginFrom", p.Path)})
+		}
+		groups = append(groups, &CmdGroup{Name: "plugins", Title: gi18n.T("help.plugins"), Cmds: cmds})
+	}
+	return groups
+}
+
 // display app version info
 func (app *App) showVersionInfo() bool {
-	Debugf("print application version info")
+	app.Debugf("print application version info")
 
 	// custom color tag, direct print by color
 	if strings.Contains(app.Version, "</>") {
-		color.Printf("Version: %s\n", app.Version)
+		app.opts.printf("Version: %s\n", app.Version)
 	} else {
-		color.Printf("Version: <cyan>%s</>\n", app.Version)
+		// with short build info. eg: "1.2.0 (abc1234, 2024-05-01T10:00:00Z)"
+		app.opts.printf("Version: <cyan>%s</>\n", app.BuildInfo().Short())
 	}
 
 	if app.Logo.Text != "" {
-		color.Printf("%s\n", color.WrapTag(app.Logo.Text, app.Logo.Style))
+		app.opts.printf("%s\n", color.WrapTag(app.Logo.Text, app.Logo.Style))
 	}
 	return false
 }
 
 // display unknown input command and similar commands tips
 func (app *App) showCommandTips(name string) {
-	Debugf("will find and show similar command tips")
+	app.Debugf("will find and show similar command tips")
 
-	color.Error.Tips(`unknown input command "<mga>%s</>"`, name)
+	app.opts.tips(color.Error, gi18n.T("tip.unknownCommand", name))
 	if ns := app.findSimilarCmd(name); len(ns) > 0 {
-		color.Printf("\nMaybe you mean:\n  <green>%s</>\n", strings.Join(ns, ", "))
+		app.opts.printf("\n%s:\n  <green>%s</>\n", gi18n.T("tip.maybeYouMean"), strings.Join(ns, ", "))
 	}
 
-	color.Printf("\nUse <cyan>%s --help</> to see available commands\n", app.Ctx.binName)
+	app.opts.println("\n" + gi18n.T("tip.seeCommands", app.Ctx.binName))
 }
 
-// AppHelpTemplate help template for app(all commands)
+// AppHelpTemplate help template for app(all commands). the data is *HelpData
+//
+// TIP: use App.HelpTemplate to customize it for an app.
 var AppHelpTemplate = `{{.Desc}} (Version: <info>{{.Version}}</>)
-<comment>Usage:</>
+<comment>{{T "help.usage"}}:</>
   {$binName} [global options...] <info>COMMAND</> [--options ...] [arguments ...]{{if .HasSubs }}
   {$binName} [global options...] <info>COMMAND</> [--options ...] <info>SUBCOMMAND</> [--options ...] [arguments ...]{{end}}
 
-<comment>Global Options:</>
+<comment>{{T "help.globalOptions"}}:</>
 {{.GOpts}}
 {{range $g := .CmdGroups}}<comment>{{$g.Title}}:</>{{range $c := $g.Cmds}}
-  <info>{{$c.Name | paddingName }}</> {{$c.HelpDesc}}{{if $c.Aliases}} (alias: <green>{{ join $c.Aliases ","}}</>){{end}}{{end}}
-{{end}}  <info>{{ paddingName "help" }}</> Display help information
+  <info>{{$c.Name | paddingName }}</> {{cmdDesc $c}}{{end}}
+{{end}}  <info>{{ paddingName "help" }}</> {{T "help.helpCommand"}}
 
-{{.Help.AfterCmdText}}Use "<cyan>{$binName} COMMAND -h</>" for more information about a command.{{.Help.FooterText}}
+{{.Help.AfterCmdText}}{{T "help.moreInfo" "{$binName}"}}{{.Help.FooterText}}
 `
 
 // display app help and list all commands. showCommandList()
 func (app *App) showApplicationHelp() bool {
-	Debugf("render application help and commands list, replaces=%s", maputil.ToString2(app.Replaces()))
+	app.Debugf("render application help and commands list, replaces=%s", maputil.ToString2(app.Replaces()))
 	app.Fire(gevent.OnAppHelpBefore, nil)
 
-	// cmdHelpTemplate = color.ReplaceTag(cmdHelpTemplate)
-	// render help text template
-	s := helper.RenderText(AppHelpTemplate, map[string]any{
-		"CmdGroups": app.CommandsByGroup("Available Commands"),
-		"GOpts":     app.fs.BuildOptsHelp(),
-		// app version
-		"Version": app.Version,
-		"HasSubs": app.hasSubcommands,
-		// always upper first char
-		"Desc": strutil.UpperFirst(app.Desc),
-		// user custom help vars
-		"Vars": app.HelpVars,
-		// custom help config
-		"Help": app.HelpConfig,
-	}, template.FuncMap{
-		"paddingName": func(n string) string {
-			return strutil.PadRight(n, " ", app.nameMaxWidth)
-		},
-	})
+	s, err := app.RenderHelp()
+	if err != nil {
+		app.opts.tips(color.Error, err.Error())
+		app.AddError(err)
+		return false
+	}
 
-	// parse help vars and render color tags
-	color.Print(app.ReplacePairs(s))
+	// render color tags and print
+	pageOutput(app.pager, app.opts, s)
 	app.Fire(gevent.OnAppHelpAfter, nil)
 
 	if sysutil.IsLinux() {
-		fmt.Println()
+		app.opts.println()
 	}
 	return false
 }
@@ -153,28 +195,29 @@ func (app *App) showCommandHelp(list []string) (code PrepareState) {
 	binName := app.Ctx.binName
 	// if len(list) == 0 { TODO support multi level sub command?
 	if len(list) > 1 {
-		color.Error.Tips("Too many arguments given.\n\nUsage: %s help COMMAND", binName)
+		app.opts.tips(color.Error, gi18n.T("tip.tooManyHelpArgs", binName))
 		return ERR
 	}
 
 	// get real name
 	name := app.cmdAliases.ResolveAlias(list[0])
 	if name == HelpCommand || name == "-h" {
-		Debugf("render help command information")
+		app.Debugf("render help command information")
 
-		color.Println("Display help message for application or command.\n")
-		color.Printf(`<yellow>Usage:</>
+		app.opts.println(gi18n.T("help.helpUsage") + "\n")
+		app.opts.printf(`<yellow>%s:</>
   <cyan>%s COMMAND --help</>
   <cyan>%s COMMAND SUBCOMMAND --help</>
   <cyan>%s COMMAND SUBCOMMAND ... --help</>
   <cyan>%s help COMMAND</>
-`, binName, binName, binName, binName)
+`, gi18n.T("help.usage"), binName, binName, binName, binName)
 		return
 	}
 
 	cmd, exist := app.Command(name)
 	if !exist {
-		color.Error.Prompt("Unknown command name '%s'. Run '%s -h' see all commands", name, binName)
+		msg := strings.ToUpper(color.Error.Name) + ": " + gi18n.T("tip.unknownHelpCmd", name, binName)
+		app.opts.println(color.Error.Sprint(msg))
 		return ERR
 	}
 
@@ -188,7 +231,7 @@ func (app *App) showCommandHelp(list []string) (code PrepareState) {
 // words 为 shell 传入、已去掉 bin 名的命令行片段; 无候选时不输出。
 func (app *App) showAutoCompletion(words []string) {
 	for _, item := range app.resolveCompletion(words) {
-		fmt.Println(item)
+		_, _ = fmt.Fprintln(app.opts.Out(), item)
 	}
 }
 
@@ -246,31 +289,33 @@ func (app *App) findSimilarCmd(input string) []string {
  * display command help
  *************************************************************/
 
-// CmdHelpTemplate help template for a command
+// CmdHelpTemplate help template for a command. the data is *HelpData
+//
+// TIP: use Command.HelpTemplate to customize it for a command.
 var CmdHelpTemplate = `{{.Desc}}
 {{if .Cmd.NotStandalone}}
-<comment>Name:</> {{.Cmd.Name}}{{if .Cmd.Aliases}} (alias: <info>{{.Cmd.Aliases.String}}</>){{end}}{{end}}
-<comment>Usage:</>
+<comment>{{T "help.name"}}:</> {{.Cmd.Name}}{{if .Cmd.Aliases}} ({{T "help.alias"}}: <info>{{.Cmd.Aliases.String}}</>){{end}}{{end}}
+<comment>{{T "help.usage"}}:</>
   {$binName} [global options] {{if .Cmd.NotStandalone}}<cyan>{{.Cmd.Path}}</> {{end}}[--options ...] [arguments ...]{{ if .SubGroups }}
   {$binName} [global options] {{if .Cmd.NotStandalone}}<cyan>{{.Cmd.Path}}</> {{end}}<cyan>SUBCOMMAND</> [--options ...] [arguments ...]{{end}}
 {{if .GOpts}}
-<comment>Global Options:</>
+<comment>{{T "help.globalOptions"}}:</>
 {{.GOpts}}{{end}}{{if .Options}}
-<comment>Options:</>
+<comment>{{T "help.options"}}:</>
 {{.Options}}{{end}}{{if .ArgsHelp}}
-<comment>Arguments:</>
+<comment>{{T "help.arguments"}}:</>
 {{.ArgsHelp}}{{end}}{{range $g := .SubGroups}}
 <comment>{{$g.Title}}:</>{{range $c := $g.Cmds}}
-  <info>{{$c.Name | paddingName }}</> {{$c.HelpDesc}}{{if $c.Aliases}} (alias: <green>{{ join $c.Aliases ","}}</>){{end}}{{end}}
-{{end}}{{.Help.AfterCmdText}}{{if .Cmd.Examples}}
-<comment>Examples:</>
-{{.Cmd.Examples}}{{end}}{{if .Cmd.Help}}
-<comment>Help:</>
-{{.Cmd.Help}}{{end}}{{.Help.FooterText}}`
+  <info>{{$c.Name | paddingName }}</> {{cmdDesc $c}}{{end}}
+{{end}}{{.Help.AfterCmdText}}{{if .Examples}}
+<comment>{{T "help.examples"}}:</>
+{{.Examples}}{{end}}{{if .CmdHelp}}
+<comment>{{T "help.help"}}:</>
+{{.CmdHelp}}{{end}}{{.Help.FooterText}}`
 
 // ShowHelp show command help information
 func (c *Command) ShowHelp() (err error) {
-	Debugf("render the command '%s' help information", c.Name)
+	c.Debugf("render the command '%s' help information", c.Name)
 
 	// custom help render func
 	if c.HelpRender != nil {
@@ -281,50 +326,252 @@ func (c *Command) ShowHelp() (err error) {
 	// 合并共享(继承)选项, 使 `help CMD` 等不走 parseOptions 的路径也能展示继承选项。幂等。
 	c.mergeSharedOpts()
 
-	// clear space and empty new line
-	if c.Examples != "" {
-		c.Examples = strings.Trim(c.Examples, "\n") + "\n"
-	}
-
 	// clear space and empty new line
 	if c.Help != "" {
 		c.Help = strings.TrimSpace(c.Help) + "\n"
 	}
 
-	vars := map[string]any{
-		"Cmd":       c,
-		"SubGroups": c.CommandsByGroup("Subcommands"),
-		// global options
-		// - on standalone
-		"GOpts": nil,
-		// parse options to string
-		"Options": c.Flags.BuildOptsHelp(),
-		// parse options to string
-		"ArgsHelp": c.Flags.BuildArgsHelp(),
-		// always upper first char
-		"Desc": c.HelpDesc(),
-		// user custom help vars
-		"Vars": c.HelpVars,
-		// custom help config
-		"Help": c.HelpConfig,
-	}
-
-	// if c.NotStandalone() {
-	// 	vars["GOpts"] = c.GFlags().BuildHelp()
-	// }
-
-	// render help message
-	str := helper.RenderText(CmdHelpTemplate, vars, template.FuncMap{
-		"paddingName": func(n string) string {
-			return strutil.PadRight(n, " ", c.nameMaxWidth)
-		},
-	})
+	str, err := c.RenderHelp()
+	if err != nil {
+		return err
+	}
 
-	// parse gcli help vars then print help
-	// fmt.Printf("%#v\n", s)
-	color.Print(c.ReplacePairs(str))
+	// render color tags and print
+	pageOutput(c.app != nil && c.app.pager, c.ownerOpts(), str)
 	if sysutil.IsLinux() {
-		fmt.Println()
+		c.ownerOpts().println()
 	}
 	return
 }
+
+/*************************************************************
+ * help template data and render
+ *************************************************************/
+
+// HelpData the data for render the app or command help template.
+//
+// TIP: the template can use the Opts, Args, CmdGroups and SubGroups for custom render,
+// the GOpts, Options and ArgsHelp are pre-rendered text for the default templates.
+type HelpData struct {
+	// App the application, nil on the standalone command
+	App *App
+	// Cmd the command, nil on render the app help
+	Cmd *Command
+	// Name the app or command name
+	Name string
+	// Desc the description, always upper first char
+	Desc string
+	// Version the app version
+	Version string
+	// HasSubs the app has subcommands
+	HasSubs bool
+	// CmdGroups the top commands grouped by category. on render app help.
+	CmdGroups []*CmdGroup
+	// SubGroups the subcommands grouped by category. on render command help.
+	SubGroups []*CmdGroup
+	// Opts the visible options of the app(global options) or command, sorted by name.
+	Opts []*gflag.CliOpt
+	// Args the arguments of the command
+	Args []*gflag.CliArg
+	// GOpts the pre-rendered global options help. on render app help.
+	GOpts string
+	// Options the pre-rendered command options help. on render command help.
+	Options string
+	// ArgsHelp the pre-rendered command arguments help. on render command help.
+	ArgsHelp string
+	// Examples the command examples for the current locale. on render command help.
+	Examples string
+	// CmdHelp the command long help for the current locale. on render command help.
+	CmdHelp string
+	// Vars user custom help vars. see AddHelpVar
+	Vars map[string]any
+	// Help custom help config
+	Help HelpConfig
+}
+
+// visibleOpts get the visible options, sorted by name.
+func visibleOpts(opts map[string]*gflag.CliOpt) []*gflag.CliOpt {
+	list := make([]*gflag.CliOpt, 0, len(opts))
+	for _, opt := range opts {
+		if opt.Visible() {
+			list = append(list, opt)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// AddHelpFunc add a custom template function for render help.
+// the command will inherit the functions of the app.
+func (b *base) AddHelpFunc(name string, fn any) {
+	if b.helpFuncs == nil {
+		b.helpFuncs = make(template.FuncMap)
+	}
+	b.helpFuncs[name] = fn
+}
+
+// AddHelpFuncs add custom template functions for render help. see AddHelpFunc
+func (b *base) AddHelpFuncs(fns template.FuncMap) {
+	for name, fn := range fns {
+		b.AddHelpFunc(name, fn)
+	}
+}
+
+// setHelpTemplate set and check the custom help template. parent is the inherited functions.
+func (b *base) setHelpTemplate(tpl string, parent template.FuncMap) error {
+	// check the template syntax and functions.
+	if _, err := helper.ParseText(tpl, b.helpFuncMap(0, 0, parent)); err != nil {
+		return fmt.Errorf("parse help template: %w", err)
+	}
+
+	b.HelpTemplate = tpl
+	return nil
+}
+
+// SetHelpTemplate set and check the custom help template. the custom functions should be added before it.
+func (app *App) SetHelpTemplate(tpl string) error { return app.setHelpTemplate(tpl, nil) }
+
+// LoadHelpTemplate load the custom help template from the file system. eg: embed.FS
+//
+// Usage:
+//
+//	//go:embed templates/*.tpl
+//	var tplFS embed.FS
+//
+//	err := app.LoadHelpTemplate(tplFS, "templates/app-help.tpl")
+func (app *App) LoadHelpTemplate(fsys fs.FS, file string) error {
+	bs, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+	return app.SetHelpTemplate(string(bs))
+}
+
+// SetHelpTemplate set and check the custom help template. the custom functions should be added before it.
+//
+// NOTE: the command should be added to the app first, if it uses the functions of the app.
+func (c *Command) SetHelpTemplate(tpl string) error {
+	var parentFns template.FuncMap
+	if c.app != nil {
+		parentFns = c.app.helpFuncs
+	}
+	return c.setHelpTemplate(tpl, parentFns)
+}
+
+// LoadHelpTemplate load the custom help template from the file system. eg: embed.FS
+func (c *Command) LoadHelpTemplate(fsys fs.FS, file string) error {
+	bs, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+	return c.SetHelpTemplate(string(bs))
+}
+
+// helpFuncMap build the template functions for render help. parent is the inherited functions.
+func (b *base) helpFuncMap(nameWidth, width int, parent template.FuncMap) template.FuncMap {
+	fns := template.FuncMap{
+		"paddingName": func(n string) string {
+			return strutil.PadRight(n, " ", nameWidth)
+		},
+		"cmdDesc": func(c *Command) string {
+			return cmdListDesc(c, nameWidth, width)
+		},
+		// wrap text to the help width. usage: {{ wrap .Desc 4 }}
+		"wrap": func(s string, indent int) string {
+			return gflag.WrapText(s, indent, width)
+		},
+		// translate the message by key. usage: {{ T "help.usage" }}
+		"T": gi18n.T,
+	}
+
+	for name, fn := range parent {
+		fns[name] = fn
+	}
+	for name, fn := range b.helpFuncs {
+		fns[name] = fn
+	}
+	return fns
+}
+
+// RenderHelp render the app help text by the HelpTemplate or AppHelpTemplate.
+//
+// the help vars(eg: {$binName}) are replaced, but the color tags are kept.
+func (app *App) RenderHelp() (string, error) {
+	width := helpWidth(app.fs, app.HelpConfig.Width)
+	data := &HelpData{
+		App:       app,
+		Name:      app.Name,
+		Desc:      strutil.UpperFirst(app.Desc),
+		Version:   app.Version,
+		HasSubs:   app.hasSubcommands,
+		CmdGroups: app.helpCmdGroups(),
+		Opts:      visibleOpts(app.fs.Opts()),
+		GOpts:     app.fs.BuildOptsHelp(),
+		Vars:      app.HelpVars,
+		Help:      app.HelpConfig,
+	}
+
+	tpl := app.HelpTemplate
+	if tpl == "" {
+		tpl = AppHelpTemplate
+	}
+
+	s, err := helper.RenderTextE(tpl, data, app.helpFuncMap(app.nameMaxWidth, width, nil))
+	if err != nil {
+		return "", fmt.Errorf("render app help: %w", err)
+	}
+	return app.ReplacePairs(s), nil
+}
+
+// RenderHelp render the command help text by the HelpTemplate or CmdHelpTemplate.
+//
+// the help vars(eg: {$binName}) are replaced, but the color tags are kept.
+func (c *Command) RenderHelp() (string, error) {
+	// inherit the help width and functions from the app
+	width := c.HelpConfig.Width
+	var parentFns template.FuncMap
+	if c.app != nil {
+		if width == 0 {
+			width = c.app.HelpConfig.Width
+		}
+		parentFns = c.app.helpFuncs
+	}
+	width = helpWidth(&c.Flags, width)
+
+	// clear space and empty new line
+	ct := c.LocaleText()
+	if ct.Examples != "" {
+		ct.Examples = strings.Trim(ct.Examples, "\n") + "\n"
+	}
+
+	data := &HelpData{
+		App:       c.app,
+		Cmd:       c,
+		Name:      c.Name,
+		Desc:      c.HelpDesc(),
+		Version:   c.Version,
+		SubGroups: c.CommandsByGroup(gi18n.T("help.subcommands")),
+		Opts:      visibleOpts(c.Flags.Opts()),
+		Args:      c.Flags.Args(),
+		Options:   c.Flags.BuildOptsHelp(),
+		ArgsHelp:  c.Flags.BuildArgsHelp(),
+		Examples:  ct.Examples,
+		CmdHelp:   ct.Help,
+		Vars:      c.HelpVars,
+		Help:      c.HelpConfig,
+	}
+
+	tpl := c.HelpTemplate
+	if tpl == "" {
+		tpl = CmdHelpTemplate
+	}
+
+	s, err := helper.RenderTextE(tpl, data, c.helpFuncMap(c.nameMaxWidth, width, parentFns))
+	if err != nil {
+		return "", fmt.Errorf("render command %q help: %w", c.Path(), err)
+	}
+	return c.ReplacePairs(s), nil
+}
@@ -0,0 +1,47 @@
+package gcli
+
+import "github.com/gookit/gcli/v3/gi18n"
+
+// Catalog the messages of a locale. alias of gi18n.Catalog
+type Catalog = gi18n.Catalog
+
+// CmdText the command texts for a locale. see Command.I18n
+//
+// Usage:
+//
+//	cmd := &gcli.Command{
+//		Name: "serve",
+//		Desc: "start the http server",
+//		I18n: map[string]gcli.CmdText{
+//			"zh-CN": {Desc: "启动 http 服务", Help: "..."},
+//		},
+//	}
+type CmdText struct {
+	Desc string
+	Help string
+	// Examples usage examples, can use help vars. eg: {$binName}
+	Examples string
+}
+
+// SetLocale set the locale for the built-in help and error messages. eg: "zh-CN"
+//
+// will detect it from the env LC_ALL, LC_MESSAGES, LANG on name is empty.
+//
+// NOTE: should be called before the app run, the built-in option descriptions are translated on binding.
+func SetLocale(name string) { gi18n.SetLocale(name) }
+
+// Locale get the current locale. default is detected from the env, fallback is "en".
+func Locale() string { return gi18n.Locale() }
+
+// AddCatalog add or override the messages for the locale. see gi18n.Catalog for the message keys.
+//
+// Usage:
+//
+//	gcli.AddCatalog("fr", gcli.Catalog{
+//		"help.usage":   "Utilisation",
+//		"help.options": "Options",
+//	})
+func AddCatalog(locale string, c Catalog) { gi18n.AddCatalog(locale, c) }
+
+// T translate the message by key for the current locale. see gi18n.T
+func T(key string, args ...any) string { return gi18n.T(key, args...) }
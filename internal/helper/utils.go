@@ -3,11 +3,14 @@ package helper
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"regexp"
 	"strings"
 	"text/template"
 
 	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/sysutil"
 )
 
 const (
@@ -30,6 +33,37 @@ var (
 	goodCmdName = regexp.MustCompile(RegGoodCmdName)
 )
 
+// IsTerminal check the fd(eg: os.Stdin.Fd(), os.Stdout.Fd()) is a terminal.
+//
+// NOTE: it can be mocked on tests, see gclitest.WithTTY
+var IsTerminal = sysutil.IsTerminal
+
+// ReadLine read a line from the reader, returns the trimmed line without the line end.
+//
+// it reads byte by byte, so the remaining contents are not consumed for the next read.
+// returns io.EOF only on nothing is read.
+func ReadLine(r io.Reader) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+			line = append(line, buf[0])
+		}
+
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				break
+			}
+			return "", err
+		}
+	}
+	return strings.TrimSpace(string(line)), nil
+}
+
 // IsGoodName check
 func IsGoodName(name string) bool {
 	return goodName.MatchString(name)
@@ -50,10 +84,26 @@ func Panicf(format string, v ...any) {
 	panic(fmt.Sprintf("GCli: "+format, v...))
 }
 
-// RenderText render text template with data. TODO use strutil.RenderText()
+// RenderText render text template with data. will panic on parse or execute error. TODO use strutil.RenderText()
 func RenderText(input string, data any, fns template.FuncMap, isFile ...bool) string {
-	t := template.New("cli")
-	t.Funcs(template.FuncMap{
+	if len(isFile) > 0 && isFile[0] {
+		bs, err := os.ReadFile(input)
+		if err != nil {
+			panic(err)
+		}
+		input = string(bs)
+	}
+
+	s, err := RenderTextE(input, data, fns)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// BaseFuncs the base template functions for render text.
+func BaseFuncs() template.FuncMap {
+	return template.FuncMap{
 		// don't escape content
 		"raw": func(s string) string {
 			return s
@@ -67,24 +117,31 @@ func RenderText(input string, data any, fns template.FuncMap, isFile ...bool) st
 		"lcFirst": strutil.LowerFirst,
 		// upper first char
 		"ucFirst": strutil.UpperFirst,
-	})
+	}
+}
+
+// ParseText parse the text template with the base and custom functions.
+func ParseText(input string, fns template.FuncMap) (*template.Template, error) {
+	t := template.New("cli").Funcs(BaseFuncs())
 
 	// custom add template functions
 	if len(fns) > 0 {
 		t.Funcs(fns)
 	}
+	return t.Parse(input)
+}
 
-	if len(isFile) > 0 && isFile[0] {
-		template.Must(t.ParseFiles(input))
-	} else {
-		template.Must(t.Parse(input))
+// RenderTextE render text template with data, returns the parse or execute error.
+func RenderTextE(input string, data any, fns template.FuncMap) (string, error) {
+	t, err := ParseText(input, fns)
+	if err != nil {
+		return "", err
 	}
 
 	// use buffer receive rendered content
 	var buf bytes.Buffer
-	if err := t.Execute(&buf, data); err != nil {
-		panic(err)
+	if err = t.Execute(&buf, data); err != nil {
+		return "", err
 	}
-
-	return buf.String()
+	return buf.String(), nil
 }
@@ -1,12 +1,17 @@
 package gcli
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
 	"strings"
 	"testing"
 
+	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3/gevent"
 	"github.com/gookit/gcli/v3/gflag"
 	"github.com/gookit/goutil/x/assert"
@@ -90,14 +95,17 @@ func newCompletionApp() *App {
 	app := NewApp(func(a *App) { a.ExitOnEnd = false })
 
 	build := NewCommand("build", "build desc", func(c *Command) {
-		var out, verbose, format string
+		var out, verbose, format, color string
 		var force bool
 		c.StrOpt(&out, "output", "o", "the output dir")
 		c.StrOpt(&verbose, "verbose", "v", "verbose mode")
 		// 带候选值的取值型选项, 用于选项值补全
-		c.StrOpt2(&format, "format,f", "output format", gflag.WithChoices("json", "yaml", "text"))
+		c.StrOpt2(&format, "format,fmt,f", "output format", gflag.WithChoices("json", "yaml", "text"))
 		// bool 选项: 不取值, 其后补全应落到子命令
 		c.BoolOpt(&force, "force", "F", false, "force build")
+		// 可选值选项: 不消费下一个词, 值只能用 = 给出
+		c.StrOpt2(&color, "color", "color mode", gflag.WithOptionalValue("auto"),
+			gflag.WithChoices("auto", "always", "never"))
 
 		c.AddSubs(NewCommand("module", "module desc", func(sc *Command) {
 			sc.Aliases = []string{"mod"}
@@ -188,6 +196,14 @@ func TestApp_resolveCompletion(t *testing.T) {
 		is.Contains(got, "-n")
 	})
 
+	t.Run("long alias options", func(t *testing.T) {
+		got := app.resolveCompletion([]string{"build", "--f"})
+		is.Eq([]string{"--fmt", "--force", "--format"}, got)
+		// 别名引用同样补全选项值
+		got = app.resolveCompletion([]string{"build", "--fmt", "y"})
+		is.Eq([]string{"yaml"}, got)
+	})
+
 	t.Run("hidden global option excluded", func(t *testing.T) {
 		// 顶层选项补全: 可见全局选项可补全, 但隐藏的内部选项 --in-completion 不应出现
 		got := app.resolveCompletion([]string{"-"})
@@ -224,6 +240,20 @@ func TestApp_resolveCompletion(t *testing.T) {
 		got := app.resolveCompletion([]string{"build", "--force", ""})
 		is.Eq([]string{"mod", "module"}, got)
 	})
+
+	t.Run("optional value option falls through to subcommands", func(t *testing.T) {
+		// --color 值可选, 不消费下一个词, 其后应补全 build 的子命令
+		got := app.resolveCompletion([]string{"build", "--color", ""})
+		is.Eq([]string{"mod", "module"}, got)
+	})
+
+	t.Run("option value choices with equal sign", func(t *testing.T) {
+		got := app.resolveCompletion([]string{"build", "--color="})
+		is.Eq([]string{"--color=always", "--color=auto", "--color=never"}, got)
+
+		got = app.resolveCompletion([]string{"build", "--color=a"})
+		is.Eq([]string{"--color=always", "--color=auto"}, got)
+	})
 }
 
 // captureStdout 捕获 fn 执行期间写入 os.Stdout 的内容(showAutoCompletion 用 fmt.Println 直接写 stdout)。
@@ -313,3 +343,465 @@ func TestApp_completionMode_hooksFireNormally(t *testing.T) {
 	app.Run([]string{"--version"})
 	is.True(hookFired, "OnAppInitAfter hook SHOULD fire in normal mode")
 }
+
+// captureStderr 捕获 fn 执行期间写入 os.Stderr 的内容(弃用警告写 stderr)。
+func captureStderr(fn func()) string {
+	old := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	fn()
+
+	_ = w.Close()
+	os.Stderr = old
+
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestCommand_deprecated(t *testing.T) {
+	is := assert.New(t)
+	app := NewApp(func(a *App) { a.ExitOnEnd = false })
+
+	var ran bool
+	var name string
+	app.Add(NewCommand("old-run", "old run desc", func(c *Command) {
+		c.StrOpt2(&name, "user-name", "the user name", gflag.WithDeprecatedNames("uname"))
+	}).WithDeprecated("use 'run' instead").WithFunc(func(c *Command, _ []string) error {
+		ran = true
+		return nil
+	}))
+	app.Add(NewCommand("run", "run desc"))
+
+	is.True(app.GetCommand("old-run").IsDeprecated())
+	is.False(app.GetCommand("old-run").Visible())
+
+	t.Run("hidden on completion", func(t *testing.T) {
+		is.Eq([]string{"help", "run"}, app.resolveCompletion(nil))
+		is.Eq([]string{"--user-name"}, app.resolveCompletion([]string{"old-run", "--u"}))
+	})
+
+	t.Run("still runnable with warning", func(t *testing.T) {
+		out := captureStderr(func() {
+			is.Eq(0, app.Run([]string{"old-run", "--uname", "tom"}))
+		})
+		is.True(ran)
+		is.Eq("tom", name)
+		is.StrContains(out, "command 'old-run' is deprecated, use 'run' instead")
+		is.StrContains(out, "option '--uname' is deprecated, use '--user-name' instead")
+
+		// warning is printed only once
+		out = captureStderr(func() {
+			is.Eq(0, app.Run([]string{"old-run", "--uname", "tom"}))
+		})
+		is.Empty(out)
+
+		// printed again after reset
+		app.AppOpts().ResetWarnings()
+		out = captureStderr(func() {
+			is.Eq(0, app.Run([]string{"old-run"}))
+		})
+		is.StrContains(out, "command 'old-run' is deprecated")
+	})
+
+	// the printed warnings are recorded per app
+	t.Run("per app", func(t *testing.T) {
+		app2 := NewApp(func(a *App) { a.ExitOnEnd = false })
+		app2.Add(NewCommand("old-run", "old run desc").WithDeprecated("use 'run' instead"))
+
+		out := captureStderr(func() {
+			is.Eq(0, app2.Run([]string{"old-run"}))
+		})
+		is.StrContains(out, "command 'old-run' is deprecated")
+	})
+}
+
+func TestApp_EnablePlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skip on windows, the plugin is a shell script")
+	}
+	is := assert.New(t)
+
+	dir := t.TempDir()
+	writePlugin := func(name, script string) {
+		err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0755)
+		is.NoErr(err)
+	}
+	writePlugin("myapp-hello", `echo "args:$* bin:$GCLI_BIN_NAME cmd:$GCLI_PLUGIN_CMD"`)
+	writePlugin("myapp-fail", "exit 3")
+	writePlugin("myapp-remote-prune", `echo "prune:$* cmd:$GCLI_PLUGIN_CMD"`)
+	// same name as registered command, will be ignored
+	writePlugin("myapp-remote", "exit 5")
+	// not executable
+	is.NoErr(os.WriteFile(filepath.Join(dir, "myapp-noexec"), []byte("#!/bin/sh\n"), 0644))
+	t.Setenv("PATH", "")
+
+	app := NewApp(func(a *App) {
+		a.ExitOnEnd = false
+		a.HelpConfig.Width = -1
+	})
+	app.Add(NewCommand("remote", "remote desc", func(c *Command) {
+		c.AddSubs(NewCommand("add", "add desc"))
+	}))
+	app.EnablePlugins("myapp", dir)
+
+	t.Run("discover plugins", func(t *testing.T) {
+		ps := app.Plugins()
+		is.Len(ps, 3)
+		is.Eq("fail", ps[0].CmdPath())
+		is.Eq("hello", ps[1].CmdPath())
+		is.Eq("remote prune", ps[2].CmdPath())
+		is.Eq("remote", ps[2].Parent)
+		is.Eq(filepath.Join(dir, "myapp-remote-prune"), ps[2].Path)
+	})
+
+	t.Run("run top plugin", func(t *testing.T) {
+		out := captureStdout(func() {
+			is.Eq(0, app.Run([]string{"hello", "a", "--b"}))
+		})
+		is.StrContains(out, "args:a --b bin:"+app.BinName()+" cmd:hello")
+	})
+
+	t.Run("run sub plugin", func(t *testing.T) {
+		out := captureStdout(func() {
+			is.Eq(0, app.Run([]string{"remote", "prune", "origin"}))
+		})
+		is.StrContains(out, "prune:origin cmd:remote prune")
+	})
+
+	t.Run("plugin exit code", func(t *testing.T) {
+		is.Eq(3, app.Run([]string{"fail"}))
+		is.Err(app.LastError())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		is.Eq(ERR.ToInt(), app.Run([]string{"not-exist"}))
+	})
+
+	t.Run("help and completion", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		color.SetOutput(buf)
+		defer color.ResetOptions()
+
+		is.Eq(0, app.Run([]string{"--help"}))
+		is.StrContains(buf.String(), "Plugins:")
+		is.StrContains(buf.String(), "Plugin: "+filepath.Join(dir, "myapp-hello"))
+
+		is.Eq([]string{"fail", "hello", "help", "remote"}, app.resolveCompletion(nil))
+		is.Eq([]string{"add", "prune"}, app.resolveCompletion([]string{"remote", ""}))
+	})
+}
+
+func TestApp_UserAliases(t *testing.T) {
+	is := assert.New(t)
+
+	var short, confirm bool
+	var env string
+	var gotArgs []string
+	app := NewApp(func(a *App) { a.ExitOnEnd = false })
+	app.Add(NewCommand("status", "status desc", func(c *Command) {
+		c.BoolOpt(&short, "short", "s", false, "short output")
+		c.AddArg("paths", "the paths", false, true)
+		c.Func = func(c *Command, _ []string) error {
+			gotArgs = c.Arg("paths").Strings()
+			return nil
+		}
+	}))
+	app.Add(NewCommand("deploy", "deploy desc", func(c *Command) {
+		c.StrOpt(&env, "env", "", "", "env name")
+		c.BoolOpt(&confirm, "confirm", "", false, "confirm")
+	}))
+	app.Add(NewCommand("remote", "remote desc", func(c *Command) {
+		c.AddSubs(NewCommand("add", "add desc", func(c *Command) {
+			c.AddArg("name", "remote name")
+			c.Func = func(c *Command, _ []string) error {
+				gotArgs = []string{c.Arg("name").String()}
+				return nil
+			}
+		}))
+	}))
+
+	err := app.ParseUserAliases(strings.NewReader(`
+# comment line
+alias.st = "status --short"
+alias.deploy-prod = deploy --env prod --confirm
+alias.status = deploy
+[user]
+name = tom
+[alias]
+ra = 'remote add'
+s2 = st
+loop1 = loop2
+loop2 = loop1
+`))
+	is.NoErr(err)
+	is.Len(app.UserAliases(), 7)
+	is.Eq("remote add", app.UserAliases()["ra"])
+
+	t.Run("expand and run", func(t *testing.T) {
+		is.Eq(0, app.Run([]string{"st", "a.txt"}))
+		is.True(short)
+		is.Eq([]string{"a.txt"}, gotArgs)
+
+		is.Eq(0, app.Run([]string{"deploy-prod"}))
+		is.Eq("prod", env)
+		is.True(confirm)
+
+		// multi-word path
+		is.Eq(0, app.Run([]string{"ra", "origin"}))
+		is.Eq([]string{"origin"}, gotArgs)
+
+		// nested alias
+		short = false
+		is.Eq(0, app.Run([]string{"s2"}))
+		is.True(short)
+	})
+
+	t.Run("command takes precedence", func(t *testing.T) {
+		short = false
+		is.Eq(0, app.Run([]string{"status", "-s"}))
+		is.True(short)
+	})
+
+	t.Run("recursion", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		color.SetOutput(buf)
+		defer color.ResetOptions()
+
+		is.Eq(ERR.ToInt(), app.Run([]string{"loop1"}))
+		is.ErrMsg(app.LastError(), "user alias recursion detected: loop1 -> loop2 -> loop1")
+		is.StrContains(buf.String(), "user alias recursion detected")
+	})
+
+	t.Run("completion", func(t *testing.T) {
+		is.Eq([]string{"deploy", "deploy-prod", "help", "loop1", "loop2", "ra", "remote", "s2", "st", "status"}, app.resolveCompletion(nil))
+		is.Eq([]string{"--short"}, app.resolveCompletion([]string{"st", "--sh"}))
+	})
+
+	t.Run("help", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		color.SetOutput(buf)
+		defer color.ResetOptions()
+
+		is.Eq(0, app.Run([]string{"--help"}))
+		is.StrContains(buf.String(), "User Aliases:")
+		is.StrContains(buf.String(), "Alias for 'status --short'")
+		is.NotContains(buf.String(), "Alias for 'deploy'")
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		err := app.ParseUserAliases(strings.NewReader("alias.bad"))
+		is.ErrMsg(err, `line 1: invalid alias line "alias.bad", must be 'name = value'`)
+
+		// invalid name returns an error, not panic
+		err = app.ParseUserAliases(strings.NewReader("# comment\nalias.-x = status"))
+		is.ErrMsg(err, `line 2: invalid user alias name "-x"`)
+		err = app.ParseUserAliases(strings.NewReader("[alias]\n = status"))
+		is.ErrMsg(err, `line 2: invalid user alias name ""`)
+		err = app.ParseUserAliases(strings.NewReader("alias.st = "))
+		is.ErrMsg(err, `line 1: invalid alias "alias.st ="`)
+	})
+}
+
+func TestApp_BuildInfo(t *testing.T) {
+	is := assert.New(t)
+
+	oldFn := readBuildInfo
+	defer func() { readBuildInfo = oldFn }()
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.22.1",
+			Main:      debug.Module{Path: "example.com/myapp", Version: "(devel)"},
+			Deps: []*debug.Module{
+				{Path: "github.com/gookit/color", Version: "v1.5.0"},
+				{Path: "github.com/gookit/goutil", Version: "v0.6.0", Replace: &debug.Module{Path: "../goutil"}},
+			},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "0123456789abcdef"},
+				{Key: "vcs.time", Value: "2024-05-01T10:00:00Z"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+
+	app := NewApp(func(a *App) {
+		a.Name = "myapp"
+		a.Version = "1.2.0"
+		a.ExitOnEnd = false
+	})
+
+	bi := app.BuildInfo()
+	is.Eq("1.2.0", bi.Version)
+	is.Eq("0123456789abcdef", bi.Commit)
+	is.True(bi.Dirty)
+	is.Eq("go1.22.1", bi.GoVersion)
+	is.Eq("example.com/myapp", bi.Module)
+	is.Len(bi.Deps, 2)
+	is.Eq("../goutil", bi.Deps[1].Replace)
+	is.Eq("1.2.0 (0123456-dirty, 2024-05-01T10:00:00Z)", bi.Short())
+
+	str := bi.Format(true)
+	is.StrContains(str, "myapp version 1.2.0")
+	is.StrContains(str, "Commit:      0123456789abcdef (dirty)")
+	is.StrContains(str, "Commit time: 2024-05-01T10:00:00Z")
+	is.NotContains(str, "Build time:")
+	is.Eq("2024-05-01T10:00:00Z", bi.CommitTime)
+	is.Eq("", bi.BuildTime)
+	is.StrContains(str, "github.com/gookit/goutil v0.6.0 => ../goutil")
+	is.NotContains(bi.String(), "Deps:")
+	is.StrContains(bi.JSON(), `"commit": "0123456789abcdef"`)
+	is.StrContains(bi.JSON(), `"path": "github.com/gookit/color"`)
+
+	t.Run("ldflags values", func(t *testing.T) {
+		BuildCommit, BuildTime = "fedcba9", "2024-06-01"
+		defer func() { BuildCommit, BuildTime = "", "" }()
+
+		bi := app.BuildInfo()
+		is.Eq("fedcba9", bi.Commit)
+		is.Eq("2024-06-01", bi.BuildTime)
+		is.Eq("2024-05-01T10:00:00Z", bi.CommitTime)
+		is.Eq("1.2.0 (fedcba9-dirty, 2024-06-01)", bi.Short())
+	})
+
+	t.Run("module version", func(t *testing.T) {
+		fn := readBuildInfo
+		defer func() { readBuildInfo = fn }()
+		readBuildInfo = func() (*debug.BuildInfo, bool) {
+			return &debug.BuildInfo{Main: debug.Module{Path: "example.com/myapp", Version: "v1.3.0"}}, true
+		}
+
+		// the default version
+		app := NewApp()
+		is.Eq("0.1.0-dev", app.Version)
+		is.Eq("v1.3.0", app.BuildInfo().Version)
+		app.Version = ""
+		is.Eq("v1.3.0", app.BuildInfo().Version)
+
+		// the app version is set
+		app.Version = "1.2.0"
+		is.Eq("1.2.0", app.BuildInfo().Version)
+	})
+
+	t.Run("short --version", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		color.SetOutput(buf)
+		defer color.ResetOptions()
+
+		is.Eq(0, app.Run([]string{"--version"}))
+		is.StrContains(color.ClearCode(buf.String()), "Version: 1.2.0 (0123456-dirty, 2024-05-01T10:00:00Z)")
+	})
+
+	t.Run("min version", func(t *testing.T) {
+		is.NoErr(app.RequireMinVersion("1.2.0"))
+		is.NoErr(app.RequireMinVersion("v1.1.9"))
+		is.ErrMsg(app.RequireMinVersion("1.10.0"), "require version >= 1.10.0, but current version is 1.2.0")
+		is.Err(CheckMinVersion("", "1.0.0"))
+	})
+}
+
+func TestApp_EnablePager(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skip on windows, the pager is run by sh")
+	}
+	is := assert.New(t)
+
+	oldFn := outIsTerminal
+	defer func() { outIsTerminal = oldFn }()
+	outIsTerminal = func(io.Writer) bool { return true }
+
+	t.Setenv("LINES", "5")
+	t.Setenv(PagerEnvName, "sed 's/^/> /'")
+	t.Setenv(NoPagerEnvName, "")
+	is.Eq("sed 's/^/> /'", PagerCommand())
+
+	newApp := func(enable ...bool) *App {
+		app := NewApp(func(a *App) { a.ExitOnEnd = false })
+		if len(enable) == 0 || enable[0] {
+			app.EnablePager()
+		}
+		app.Add(NewCommand("list", "list items", func(c *Command) {
+			c.Func = func(c *Command, _ []string) error {
+				p := c.Pager()
+				defer p.Close()
+				for i := 0; i < 10; i++ {
+					_, _ = fmt.Fprintf(p, "item%d\n", i)
+				}
+				return nil
+			}
+		}))
+		return app
+	}
+
+	t.Run("page help", func(t *testing.T) {
+		out := captureStdout(func() {
+			is.Eq(0, newApp().Run([]string{"--help"}))
+		})
+		is.StrContains(color.ClearCode(out), "> Global Options:")
+		is.StrContains(out, "--no-pager")
+	})
+
+	t.Run("command pager", func(t *testing.T) {
+		out := captureStdout(func() {
+			is.Eq(0, newApp().Run([]string{"list"}))
+		})
+		is.StrContains(out, "> item0\n")
+		is.StrContains(out, "> item9\n")
+	})
+
+	t.Run("not enabled by app", func(t *testing.T) {
+		out := captureStdout(func() {
+			is.Eq(0, newApp(false).Run([]string{"list"}))
+		})
+		is.StrContains(out, "item0\nitem1\n")
+		is.NotContains(out, ">")
+	})
+
+	t.Run("short output", func(t *testing.T) {
+		t.Setenv("LINES", "50")
+		out := captureStdout(func() {
+			p := NewPager(nil)
+			_, _ = p.WriteString("line1\nline2\n")
+			is.NoErr(p.Close())
+		})
+		is.Eq("line1\nline2\n", out)
+	})
+
+	t.Run("pager not started", func(t *testing.T) {
+		for _, pagerCmd := range []string{"no-such-pager-xyz", "LESS=-R no-such-pager-xyz", "sh -c 'exit 127'"} {
+			t.Setenv(PagerEnvName, pagerCmd)
+			out := captureStdout(func() {
+				is.Eq(0, newApp().Run([]string{"list"}))
+			})
+			is.StrContains(out, "item0\nitem1\n", pagerCmd)
+			is.StrContains(out, "item9\n", pagerCmd)
+		}
+
+		is.True(pagerExists("LESS=-R sh -c cat"))
+		is.False(pagerExists("LESS=-R"))
+	})
+
+	t.Run("no-pager option", func(t *testing.T) {
+		out := captureStdout(func() {
+			is.Eq(0, newApp().Run([]string{"--no-pager", "list"}))
+		})
+		is.StrContains(out, "item0\nitem1\n")
+		is.NotContains(out, ">")
+	})
+
+	t.Run("disabled by env", func(t *testing.T) {
+		t.Setenv(NoPagerEnvName, "1")
+		out := captureStdout(func() {
+			is.Eq(0, newApp().Run([]string{"list"}))
+		})
+		is.NotContains(out, ">")
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		outIsTerminal = func(io.Writer) bool { return false }
+		out := captureStdout(func() {
+			is.Eq(0, newApp().Run([]string{"list"}))
+		})
+		is.StrContains(out, "item0\n")
+		is.NotContains(out, ">")
+	})
+}
@@ -0,0 +1,235 @@
+package gcli
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/gcli/v3/gi18n"
+	"github.com/gookit/goutil/arrutil"
+)
+
+// env name and formats for the slog logger
+const (
+	// LogFormatEnvName set the process default log format, the diagnostics will be
+	// written to stderr by log/slog. eg: GCLI_LOG_FORMAT=json
+	LogFormatEnvName = "GCLI_LOG_FORMAT"
+	// LogFormatText the slog text format. eg: `time=... level=DEBUG msg=...`
+	LogFormatText = "text"
+	// LogFormatJSON the slog JSON format, friendly for CI and the log collectors.
+	LogFormatJSON = "json"
+)
+
+// the slog levels for VerbQuiet and VerbCrazy, out of the slog builtin levels.
+const (
+	slogLevelQuiet = slog.LevelError + 4
+	slogLevelCrazy = slog.LevelDebug - 4
+)
+
+// logLevelNames the allowed level names for the --log-level option
+var logLevelNames = []string{"quiet", "error", "warn", "info", "debug", "crazy"}
+
+// VerbToSlog map the verbose level to slog.Level.
+//
+// VerbError, VerbWarn, VerbInfo, VerbDebug map to the same slog levels,
+// VerbQuiet is higher than slog.LevelError, VerbCrazy is lower than slog.LevelDebug.
+func VerbToSlog(verb VerbLevel) slog.Level {
+	switch verb {
+	case VerbQuiet:
+		return slogLevelQuiet
+	case VerbError:
+		return slog.LevelError
+	case VerbWarn:
+		return slog.LevelWarn
+	case VerbInfo:
+		return slog.LevelInfo
+	case VerbDebug:
+		return slog.LevelDebug
+	}
+	return slogLevelCrazy
+}
+
+// VerbFromSlog map the slog.Level to verbose level. see VerbToSlog
+func VerbFromSlog(level slog.Level) VerbLevel {
+	switch {
+	case level >= slogLevelQuiet:
+		return VerbQuiet
+	case level >= slog.LevelError:
+		return VerbError
+	case level >= slog.LevelWarn:
+		return VerbWarn
+	case level >= slog.LevelInfo:
+		return VerbInfo
+	case level >= slog.LevelDebug:
+		return VerbDebug
+	}
+	return VerbCrazy
+}
+
+// verbLeveler resolve the slog level from the verbose level of the app options.
+// nil opts for the process default verbose level.
+type verbLeveler struct {
+	opts *AppOptions
+}
+
+// Level implements slog.Leveler
+func (l verbLeveler) Level() slog.Level {
+	if l.opts == nil {
+		return VerbToSlog(gOpts.Verbose)
+	}
+	return VerbToSlog(l.opts.Verbose())
+}
+
+// NewLogHandler create a slog handler by the format(LogFormatText, LogFormatJSON), writes to w.
+//
+//   - w is nil will write to os.Stderr
+//   - level is nil will use the process default verbose level(see SetVerbose). App.SetLogFormat
+//     use the verbose level of the app.
+//   - the level attr is rendered as the verbose level name. eg: "DEBUG", "CRAZY"
+//
+// Usage:
+//
+//	gcli.SetLogger(slog.New(gcli.NewLogHandler(nil, gcli.LogFormatJSON, nil)))
+func NewLogHandler(w io.Writer, format string, level slog.Leveler) slog.Handler {
+	if w == nil {
+		w = os.Stderr
+	}
+	if level == nil {
+		level = verbLeveler{}
+	}
+
+	opts := &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.LevelKey {
+				if lv, ok := a.Value.Any().(slog.Level); ok {
+					verb := VerbFromSlog(lv)
+					a.Value = slog.StringValue(verb.Upper())
+				}
+			}
+			return a
+		},
+	}
+
+	if strings.ToLower(format) == LogFormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// checkLogFormat check the log format is valid
+func checkLogFormat(format string) error {
+	if format == "" || arrutil.StringsHas([]string{LogFormatText, LogFormatJSON}, strings.ToLower(format)) {
+		return nil
+	}
+	return errors.New(gi18n.T("err.notInChoices", format, []string{LogFormatText, LogFormatJSON}))
+}
+
+// checkLogLevel check the log level is a valid level name or int value(0-5)
+func checkLogLevel(level string) error {
+	if _, err := strconv.Atoi(level); err == nil || level == "" {
+		return nil
+	}
+	if arrutil.StringsHas(logLevelNames, strings.ToLower(level)) {
+		return nil
+	}
+	return errors.New(gi18n.T("err.notInChoices", level, logLevelNames))
+}
+
+// Logger get the process default slog logger. nil if not set.
+func Logger() *slog.Logger { return gOpts.logger }
+
+// SetLogger set the process default slog logger, the framework diagnostics(Logf, Debugf) will
+// be written to it. nil to restore the colored text output. see App.SetLogger
+func SetLogger(logger *slog.Logger) { gOpts.logger = logger }
+
+// Logger get the slog logger of the app. returns the process default if not set.
+func (o *AppOptions) Logger() *slog.Logger {
+	if o.logger != nil {
+		return o.logger
+	}
+	return gOpts.logger
+}
+
+// SetLogger for the app only. nil to inherit the process default.
+func (o *AppOptions) SetLogger(logger *slog.Logger) { o.logger = logger }
+
+// SetLogger set the slog logger for the app, the diagnostics of the app and its commands will
+// be written to it. see Command.Logger
+func (app *App) SetLogger(logger *slog.Logger) { app.opts.SetLogger(logger) }
+
+// SetLogFormat set a slog logger writes to the error output(default is stderr, see App.SetOutput)
+// by the format(LogFormatText, LogFormatJSON) for the app. the level follows the verbose level of the app.
+func (app *App) SetLogFormat(format string) error {
+	if err := checkLogFormat(format); err != nil {
+		return err
+	}
+
+	app.opts.SetLogger(slog.New(NewLogHandler(errOutWriter{app.opts}, format, verbLeveler{opts: app.opts})))
+	return nil
+}
+
+// EnableLogOpts add the app options --log-format and --log-level, for set the log format and
+// the verbose level of the app on run. eg: `./myapp --log-level debug --log-format json build`
+//
+// NOTE: they are opt-in, the global options should not pollute the option list of the app.
+func (app *App) EnableLogOpts() *App {
+	if !app.logOpts {
+		app.logOpts = true
+		app.fs.StrVar(&app.opts.logFormat, &gflag.CliOpt{
+			Name:      "log-format",
+			Desc:      gi18n.T("opt.logFormat"),
+			Choices:   []string{LogFormatText, LogFormatJSON},
+			Validator: checkLogFormat,
+		})
+		app.fs.StrVar(&app.opts.logLevel, &gflag.CliOpt{
+			Name:      "log-level",
+			Desc:      gi18n.T("opt.logLevel"),
+			Choices:   logLevelNames,
+			Validator: checkLogLevel,
+		})
+	}
+	return app
+}
+
+// applyLogOpts apply the parsed --log-level and --log-format. see EnableLogOpts
+func (app *App) applyLogOpts() {
+	if level := app.opts.logLevel; level != "" {
+		var verb VerbLevel
+		_ = verb.Set(level)
+		app.opts.SetVerbose(verb)
+	}
+	if format := app.opts.logFormat; format != "" {
+		_ = app.SetLogFormat(format) // has been validated
+	}
+}
+
+// Logger get a slog logger for the command, with the attrs: cmd(command path), pid.
+//
+// It is based on the logger of the app(or the process default) set by SetLogger. If not set,
+// returns a text logger writes to stderr, the level follows the verbose level of the app.
+//
+// Usage:
+//
+//	c.Logger().Info("deploy started", "env", env)
+//	// time=... level=INFO msg="deploy started" cmd=deploy pid=1234 env=prod
+func (c *Command) Logger() *slog.Logger {
+	opts := c.ownerOpts()
+	logger := opts.Logger()
+	if logger == nil {
+		logger = slog.New(NewLogHandler(errOutWriter{opts}, LogFormatText, verbLeveler{opts: opts}))
+	}
+
+	path, pid := c.Path(), os.Getpid()
+	if path == "" {
+		path = c.Name
+	}
+	if c.Ctx != nil && c.Ctx.PID() > 0 {
+		pid = c.Ctx.PID()
+	}
+	return logger.With("cmd", path, "pid", pid)
+}
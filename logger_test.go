@@ -0,0 +1,157 @@
+package gcli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gookit/gcli/v3"
+	"github.com/gookit/gcli/v3/gclitest"
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestVerbToSlog(t *testing.T) {
+	tests := map[gcli.VerbLevel]slog.Level{
+		gcli.VerbError: slog.LevelError,
+		gcli.VerbWarn:  slog.LevelWarn,
+		gcli.VerbInfo:  slog.LevelInfo,
+		gcli.VerbDebug: slog.LevelDebug,
+	}
+	for verb, level := range tests {
+		assert.Eq(t, level, gcli.VerbToSlog(verb))
+		assert.Eq(t, verb, gcli.VerbFromSlog(level))
+	}
+
+	assert.Gt(t, int(gcli.VerbToSlog(gcli.VerbQuiet)), int(slog.LevelError))
+	assert.Lt(t, int(gcli.VerbToSlog(gcli.VerbCrazy)), int(slog.LevelDebug))
+	for verb := gcli.VerbQuiet; verb <= gcli.VerbCrazy; verb++ {
+		assert.Eq(t, verb, gcli.VerbFromSlog(gcli.VerbToSlog(verb)))
+	}
+	assert.Eq(t, gcli.VerbInfo, gcli.VerbFromSlog(slog.LevelInfo+2))
+}
+
+// decodeLogs decode the JSON log lines
+func decodeLogs(t *testing.T, s string) []map[string]any {
+	var logs []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		if line == "" {
+			continue
+		}
+		m := make(map[string]any)
+		assert.NoErr(t, json.Unmarshal([]byte(line), &m), line)
+		logs = append(logs, m)
+	}
+	return logs
+}
+
+func newLogApp() *gcli.App {
+	app := gcli.NewApp(gcli.NotExitOnEnd())
+	app.Add(&gcli.Command{
+		Name: "deploy",
+		Func: func(c *gcli.Command, _ []string) error {
+			c.Logger().Info("deploy started", "env", "prod")
+			c.Logger().Debug("deploy details")
+			return nil
+		},
+	})
+	return app
+}
+
+func TestApp_SetLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	app := newLogApp()
+	app.SetVerbose(gcli.VerbDebug)
+	app.SetLogger(slog.New(gcli.NewLogHandler(buf, gcli.LogFormatJSON, gcli.VerbToSlog(gcli.VerbDebug))))
+
+	assert.Eq(t, 0, app.Run([]string{"deploy"}))
+	logs := decodeLogs(t, buf.String())
+	assert.NotEmpty(t, logs)
+
+	var found bool
+	for _, m := range logs {
+		msg := m["msg"].(string)
+		// color tags are removed from the framework diagnostics
+		assert.NotContains(t, msg, "</>")
+		if msg == "deploy started" {
+			found = true
+			assert.Eq(t, "INFO", m["level"])
+			assert.Eq(t, "deploy", m["cmd"])
+			assert.Eq(t, float64(os.Getpid()), m["pid"])
+			assert.Eq(t, "prod", m["env"])
+		}
+	}
+	assert.True(t, found)
+	assert.StrContains(t, buf.String(), `"level":"DEBUG","msg":"deploy details"`)
+	// framework diagnostics with the caller
+	assert.StrContains(t, buf.String(), `"caller":`)
+	// the process default is not changed
+	assert.Nil(t, gcli.Logger())
+}
+
+func TestApp_EnableLogOpts(t *testing.T) {
+	// default: the logger level follows the default verbose level(error)
+	res := gclitest.Run(newLogApp(), "deploy")
+	assert.Eq(t, 0, res.Code)
+	assert.Eq(t, "", res.Stderr)
+
+	// opt-in options
+	res = gclitest.Run(newLogApp(), "--log-format json deploy")
+	assert.StrContains(t, res.Output(), "option provided but not defined: --log-format")
+
+	res = gclitest.Run(newLogApp().EnableLogOpts(), "--log-level info deploy")
+	assert.StrContains(t, res.Stderr, `level=INFO msg="deploy started" cmd=deploy pid=`)
+	assert.NotContains(t, res.Stderr, "deploy details")
+
+	app := newLogApp().EnableLogOpts()
+	res = gclitest.Run(app, "--log-level debug --log-format json deploy")
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "", res.Stdout)
+	logs := decodeLogs(t, res.Stderr)
+	assert.Gt(t, len(logs), 2)
+	assert.StrContains(t, res.Stderr, `"level":"DEBUG","msg":"deploy details","cmd":"deploy"`)
+	assert.Eq(t, gcli.VerbDebug, app.AppOpts().Verbose())
+
+	// invalid value
+	res = gclitest.Run(newLogApp().EnableLogOpts(), "--log-format xml deploy")
+	assert.StrContains(t, res.Output(), `value "xml" is not in the allowed list`)
+
+	res = gclitest.Run(newLogApp().EnableLogOpts(), "-h")
+	assert.StrContains(t, res.Stdout, "--log-format")
+	assert.StrContains(t, res.Stdout, "--log-level")
+}
+
+// the logger level filters the diagnostics, not the verbose level of the app
+func TestApp_SetLogger_filterByLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	app := gcli.NewApp(gcli.NotExitOnEnd())
+	app.Add(&gcli.Command{
+		Name:       "old",
+		Deprecated: "use new instead",
+		Func:       func(c *gcli.Command, _ []string) error { return nil },
+	})
+
+	// verbose level is the default(error), the logger handler level is debug
+	app.SetLogger(slog.New(gcli.NewLogHandler(buf, gcli.LogFormatJSON, slog.LevelDebug)))
+	assert.Eq(t, gcli.VerbError, app.AppOpts().Verbose())
+	app.Debugf("debug message")
+	app.Logf(gcli.VerbCrazy, "crazy message")
+	assert.StrContains(t, buf.String(), `"level":"DEBUG","msg":"debug message"`)
+	assert.NotContains(t, buf.String(), "crazy message")
+
+	// the warning is written to the logger
+	buf.Reset()
+	assert.Eq(t, 0, app.Run([]string{"old"}))
+	assert.StrContains(t, buf.String(), `"level":"WARN","msg":"command 'old' is deprecated`)
+
+	// filtered by the logger level
+	buf.Reset()
+	app.AppOpts().ResetWarnings()
+	app.SetVerbose(gcli.VerbCrazy)
+	app.SetLogger(slog.New(gcli.NewLogHandler(buf, gcli.LogFormatJSON, slog.LevelError)))
+	assert.Eq(t, 0, app.Run([]string{"old"}))
+	app.Debugf("debug message")
+	assert.Eq(t, "", buf.String())
+}
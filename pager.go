@@ -0,0 +1,207 @@
+package gcli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3/gflag"
+	"github.com/gookit/gcli/v3/gi18n"
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/envutil"
+)
+
+// env names for the pager
+const (
+	// PagerEnvName the pager command, takes precedence over the PAGER. eg: "less -R"
+	PagerEnvName = "GCLI_PAGER"
+	// NoPagerEnvName disable the pager on the value is true. eg: GCLI_NO_PAGER=1
+	NoPagerEnvName = "GCLI_NO_PAGER"
+)
+
+// PagerCommand get the pager command. from the env GCLI_PAGER, PAGER, default is "less -R".
+//
+// returns empty on the pager is not available, or it is set to "cat".
+func PagerCommand() string {
+	for _, name := range []string{PagerEnvName, "PAGER"} {
+		if val, ok := os.LookupEnv(name); ok {
+			val = strings.TrimSpace(val)
+			if val == "cat" {
+				return ""
+			}
+			return val
+		}
+	}
+
+	if _, err := exec.LookPath("less"); err == nil {
+		return "less -R"
+	}
+	if _, err := exec.LookPath("more"); err == nil {
+		return "more"
+	}
+	return ""
+}
+
+// termHeight get the terminal height. will first use the env LINES.
+func termHeight() int {
+	if lines := os.Getenv("LINES"); lines != "" {
+		if h, err := strconv.Atoi(lines); err == nil && h > 0 {
+			return h
+		}
+	}
+
+	_, h := cliutil.GetTermSize()
+	return h
+}
+
+// pagerDisabled check the pager is disabled by the --no-pager option or the env.
+func pagerDisabled(opts *AppOptions) bool {
+	return opts.noPager || envutil.GetBool(NoPagerEnvName, false)
+}
+
+// Pager a writer that buffers the output, then display it by the pager on Close.
+//
+// will directly write to the stdout without the pager on:
+//
+//   - the stdout is not a terminal
+//   - the output does not exceed the terminal height
+//   - disabled by the --no-pager option or the env GCLI_NO_PAGER
+//   - the pager command is not available or cannot be started
+type Pager struct {
+	buf bytes.Buffer
+	out io.Writer
+	// disabled the pager, direct write to out.
+	disabled bool
+	// opts of the owning app, for print the debug log. nil for the process defaults.
+	opts *AppOptions
+}
+
+// NewPager create a pager writer. out is the fallback writer, default is os.Stdout.
+func NewPager(out io.Writer) *Pager {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &Pager{out: out, disabled: !outIsTerminal(out)}
+}
+
+// Write data. will be buffered until Close.
+func (p *Pager) Write(bs []byte) (int, error) {
+	if p.disabled {
+		return p.out.Write(bs)
+	}
+	return p.buf.Write(bs)
+}
+
+// WriteString data. will be buffered until Close.
+func (p *Pager) WriteString(s string) (int, error) { return p.Write([]byte(s)) }
+
+// Close display the buffered output by the pager, or write it to the out.
+func (p *Pager) Close() error {
+	if p.disabled || p.buf.Len() == 0 {
+		return nil
+	}
+	defer p.buf.Reset()
+
+	// not exceed the terminal height
+	height := termHeight()
+	if height <= 0 || bytes.Count(p.buf.Bytes(), []byte{'\n'}) < height {
+		_, err := p.out.Write(p.buf.Bytes())
+		return err
+	}
+
+	pagerCmd := PagerCommand()
+	if pagerCmd == "" || !pagerExists(pagerCmd) {
+		_, err := p.out.Write(p.buf.Bytes())
+		return err
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		fields := strings.Fields(pagerCmd)
+		cmd = exec.Command(fields[0], fields[1:]...)
+	} else {
+		// same as git, run the pager by shell. eg: PAGER="less -R"
+		cmd = exec.Command("sh", "-c", pagerCmd)
+	}
+
+	data := p.buf.Bytes()
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = p.out
+	cmd.Stderr = p.opts.ErrOut()
+	if err := cmd.Run(); err != nil {
+		p.opts.debugf("run the pager %q error: %v", pagerCmd, err)
+		// the pager not started, fallback to direct output.
+		// by shell, 126: the command cannot execute, 127: the command not found.
+		var ee *exec.ExitError
+		if !errors.As(err, &ee) || ee.ExitCode() == 126 || ee.ExitCode() == 127 {
+			_, err = p.out.Write(data)
+			return err
+		}
+	}
+	return nil
+}
+
+// pagerExists check the pager command is exists. the leading env assignments(eg: LESS=-R less) are skipped.
+func pagerExists(pagerCmd string) bool {
+	for _, field := range strings.Fields(pagerCmd) {
+		if name, _, ok := strings.Cut(field, "="); ok && name != "" && !strings.ContainsAny(name, `/\`) {
+			continue
+		}
+
+		_, err := exec.LookPath(field)
+		return err == nil
+	}
+	return false
+}
+
+// EnablePager enable the pager for display long help.
+// also add the global option --no-pager to disable it. see Pager
+func (app *App) EnablePager() *App {
+	if !app.pager {
+		app.pager = true
+		app.fs.BoolVar(&app.opts.noPager, &gflag.CliOpt{
+			Name: "no-pager",
+			Desc: gi18n.T("opt.noPager"),
+		})
+	}
+	return app
+}
+
+// pageOutput render the color tags and display the text by the pager if enabled.
+func pageOutput(enabled bool, opts *AppOptions, s string) {
+	out := opts.Out()
+	if !enabled || pagerDisabled(opts) || !outIsTerminal(out) {
+		opts.printf("%s", s)
+		return
+	}
+
+	p := NewPager(out)
+	p.opts = opts
+	_, _ = p.WriteString(color.Render(s))
+	_ = p.Close()
+}
+
+// Pager create a pager writer for the command output. must call Close() after write.
+//
+// The pager is used only on the app is enabled it(see App.EnablePager) or the command is standalone,
+// otherwise the writer outputs directly.
+//
+// Usage:
+//
+//	p := c.Pager()
+//	defer p.Close()
+//	fmt.Fprintln(p, "long output ...")
+func (c *Command) Pager() io.WriteCloser {
+	opts := c.ownerOpts()
+	p := NewPager(opts.Out())
+	p.opts = opts
+	// same as the help: the app must opt in the pager
+	p.disabled = p.disabled || (c.app != nil && !c.app.pager) || pagerDisabled(opts)
+	return p
+}
@@ -0,0 +1,273 @@
+package gcli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3/gevent"
+)
+
+// env names for pass the app context to the plugin process.
+const (
+	// PluginEnvBinName the app bin name. eg: "app"
+	PluginEnvBinName = "GCLI_BIN_NAME"
+	// PluginEnvCmdPath the plugin command path. eg: "foo", "remote foo"
+	PluginEnvCmdPath = "GCLI_PLUGIN_CMD"
+	// PluginEnvNoColor is "1" on the app color output is disabled.
+	PluginEnvNoColor = "GCLI_NO_COLOR"
+	// PluginEnvVersion the app version. can be checked by CheckMinVersion
+	PluginEnvVersion = "GCLI_APP_VERSION"
+)
+
+// Plugin an external plugin command, found from the PATH or the plugin dirs.
+type Plugin struct {
+	// Name the command name. eg: "foo"
+	Name string
+	// Parent the parent command path, empty for top-level. eg: "remote"
+	Parent string
+	// Path the executable file path. eg: "/usr/local/bin/app-remote-foo"
+	Path string
+}
+
+// CmdPath of the plugin. eg: "remote foo"
+func (p *Plugin) CmdPath() string {
+	if p.Parent == "" {
+		return p.Name
+	}
+	return p.Parent + " " + p.Name
+}
+
+type pluginConfig struct {
+	prefix string
+	dirs   []string
+	// discovered plugins, lazy scan on first use.
+	found   []*Plugin
+	scanned bool
+}
+
+// fileName of the plugin executable for the command path. eg: "app-remote-foo"
+func (pc *pluginConfig) fileName(cmdPath string) string {
+	return pc.prefix + "-" + strings.ReplaceAll(cmdPath, " ", "-")
+}
+
+// lookup the plugin executable. search the plugin dirs first, then the PATH.
+func (pc *pluginConfig) lookup(file string) string {
+	for _, dir := range pc.dirs {
+		fPath := filepath.Join(dir, file)
+		if isExecFile(fPath) {
+			return fPath
+		}
+		if runtime.GOOS == "windows" && isExecFile(fPath+".exe") {
+			return fPath + ".exe"
+		}
+	}
+
+	if fPath, err := exec.LookPath(file); err == nil {
+		return fPath
+	}
+	return ""
+}
+
+// EnablePlugins enable discover external plugin commands. it is opt-in.
+//
+// On input an unknown command `app foo`(or subcommand `app top foo`), will find the
+// executable `{prefix}-foo`(or `{prefix}-top-foo`) from the dirs and PATH,
+// then exec it with the remaining args.
+//
+//   - prefix: the plugin file prefix, default is the app bin name.
+//   - dirs: the extra dirs for find plugins, takes precedence over the PATH.
+//
+// The app context is passed to the plugin by ENV: PluginEnvBinName, PluginEnvCmdPath,
+// PluginEnvVersion, VerbEnvName and PluginEnvNoColor.
+//
+// Usage:
+//
+//	app.EnablePlugins("") // find "app-*" plugins
+func (app *App) EnablePlugins(prefix string, dirs ...string) *App {
+	if prefix == "" {
+		prefix = app.normalizeBinName()
+	}
+
+	first := app.plugins == nil
+	app.plugins = &pluginConfig{prefix: prefix, dirs: dirs}
+
+	// only register the hook listeners once.
+	if first {
+		app.On(gevent.OnAppCmdNotFound, app.runPluginHook)
+		app.On(gevent.OnCmdSubNotFound, app.runPluginHook)
+	}
+	return app
+}
+
+// PluginsEnabled check
+func (app *App) PluginsEnabled() bool { return app.plugins != nil }
+
+// Plugins get all discovered plugin commands, sorted by command path.
+//
+// Plugin that has the same name as a registered command will be ignored.
+func (app *App) Plugins() []*Plugin {
+	if app.plugins == nil {
+		return nil
+	}
+
+	if !app.plugins.scanned {
+		app.plugins.found = app.discoverPlugins()
+		app.plugins.scanned = true
+	}
+	return app.plugins.found
+}
+
+// pluginsOf get plugins of the parent command path. empty parent for top-level.
+func (app *App) pluginsOf(parent string) []*Plugin {
+	var ps []*Plugin
+	for _, p := range app.Plugins() {
+		if p.Parent == parent {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// runPluginHook try to find and run the plugin on command not found.
+func (app *App) runPluginHook(ctx *HookCtx) bool {
+	cmdPath := ctx.Str(gevent.KeyName)
+	if cmdPath == "" || app.plugins == nil {
+		return false
+	}
+	// on subcommand not found, ctx.Cmd is the parent command
+	if ctx.Cmd != nil {
+		cmdPath = ctx.Cmd.Path() + " " + cmdPath
+	}
+
+	file := app.plugins.lookup(app.plugins.fileName(cmdPath))
+	if file == "" {
+		return false
+	}
+
+	app.Debugf("found the plugin %q for command %q, will exec it", file, cmdPath)
+	ctx.WithErr(app.execPlugin(file, cmdPath, ctx.Args()))
+	return true
+}
+
+// execPlugin run the plugin executable, the exit code of it will be returned as error code.
+func (app *App) execPlugin(file, cmdPath string, args []string) error {
+	cmd := exec.Command(file, args...)
+	cmd.Stdin = app.opts.In()
+	cmd.Stdout = app.opts.Out()
+	cmd.Stderr = app.opts.ErrOut()
+
+	verb := app.opts.Verbose()
+	cmd.Env = append(os.Environ(),
+		PluginEnvBinName+"="+app.BinName(),
+		PluginEnvCmdPath+"="+cmdPath,
+		PluginEnvVersion+"="+app.Version,
+		VerbEnvName+"="+verb.Name(),
+	)
+	if app.opts.NoColor() || !color.Enable {
+		cmd.Env = append(cmd.Env, PluginEnvNoColor+"=1", "NO_COLOR=1")
+	}
+
+	if err := cmd.Run(); err != nil {
+		var ee *exec.ExitError
+		if errors.As(err, &ee) {
+			return newRunErr(ee.ExitCode(), err)
+		}
+		return newRunErr(ERR.ToInt(), err)
+	}
+	return nil
+}
+
+// discoverPlugins scan the plugin dirs and PATH, find all executable files like "{prefix}-*".
+func (app *App) discoverPlugins() []*Plugin {
+	pfx := app.plugins.prefix + "-"
+	dirs := append([]string{}, app.plugins.dirs...)
+	dirs = append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+
+	var ps []*Plugin
+	exists := make(map[string]bool)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, ent := range entries {
+			name := ent.Name()
+			if !strings.HasPrefix(name, pfx) || ent.IsDir() {
+				continue
+			}
+
+			fPath := filepath.Join(dir, name)
+			if !isExecFile(fPath) {
+				continue
+			}
+
+			// the first found takes precedence. same as the PATH lookup.
+			rest := strings.TrimSuffix(name[len(pfx):], ".exe")
+			if rest == "" || exists[rest] {
+				continue
+			}
+			exists[rest] = true
+
+			if p := app.newPlugin(rest, fPath); p != nil {
+				ps = append(ps, p)
+			}
+		}
+	}
+
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].CmdPath() < ps[j].CmdPath()
+	})
+	return ps
+}
+
+// newPlugin resolve the parent command path from the file name rest. eg: "remote-foo"
+//
+// returns nil on the plugin name is a registered command.
+func (app *App) newPlugin(rest, fPath string) *Plugin {
+	var parents []string
+	nodes := strings.Split(rest, "-")
+	cur := &app.base
+
+	// find the longest registered command name on each level. NOTE: command name may contain "-"
+	for len(nodes) > 1 {
+		found := false
+		for n := len(nodes) - 1; n > 0; n-- {
+			name := strings.Join(nodes[:n], "-")
+			if c, ok := cur.commands[name]; ok {
+				parents = append(parents, name)
+				cur, nodes = &c.base, nodes[n:]
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+	name := strings.Join(nodes, "-")
+	if _, ok := cur.commands[name]; ok {
+		return nil
+	}
+	return &Plugin{Name: name, Parent: strings.Join(parents, " "), Path: fPath}
+}
+
+// isExecFile check the path is an executable regular file.
+func isExecFile(fPath string) bool {
+	fi, err := os.Stat(fPath)
+	if err != nil || !fi.Mode().IsRegular() {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(fPath), ".exe")
+	}
+	return fi.Mode().Perm()&0111 != 0
+}
@@ -1,5 +0,0 @@
-
-var {{.Name}}Cmd = &gcli.Command {
-    Name: "{{ .Name }}",
-    Name: "{{ .Desc }}",
-}
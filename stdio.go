@@ -0,0 +1,113 @@
+package gcli
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/gcli/v3/internal/helper"
+)
+
+// outIsTerminal check the output writer is a terminal. for mock on tests
+var outIsTerminal = func(w io.Writer) bool { return isTerminal(w) }
+
+// inIsTerminal check the input reader is a terminal. for mock on tests
+var inIsTerminal = func(r io.Reader) bool { return isTerminal(r) }
+
+// isTerminal check the reader or writer is a terminal. eg: os.Stdin, os.Stdout
+func isTerminal(v any) bool {
+	if f, ok := v.(interface{ Fd() uintptr }); ok {
+		return helper.IsTerminal(f.Fd())
+	}
+	return false
+}
+
+// In get the input reader of the app. default is os.Stdin
+func (o *AppOptions) In() io.Reader {
+	if o != nil && o.in != nil {
+		return o.in
+	}
+	return os.Stdin
+}
+
+// Out get the output writer of the app. default is os.Stdout
+func (o *AppOptions) Out() io.Writer {
+	if o != nil && o.out != nil {
+		return o.out
+	}
+	return os.Stdout
+}
+
+// ErrOut get the error output writer of the app. default is os.Stderr
+func (o *AppOptions) ErrOut() io.Writer {
+	if o != nil && o.errOut != nil {
+		return o.errOut
+	}
+	return os.Stderr
+}
+
+// SetInput set the input reader, only for the app. nil for the default os.Stdin
+func (o *AppOptions) SetInput(in io.Reader) { o.in = in }
+
+// SetOutput set the output and error output writers, only for the app. nil for the defaults.
+func (o *AppOptions) SetOutput(out, errOut io.Writer) { o.out, o.errOut = out, errOut }
+
+// printf render the color tags and print to the app output.
+// the color output(see color.SetOutput) is used on the output is not set.
+func (o *AppOptions) printf(format string, v ...any) {
+	if o != nil && o.out != nil {
+		color.Fprintf(o.out, format, v...)
+	} else {
+		color.Printf(format, v...)
+	}
+}
+
+// println render the color tags and print a line to the app output. see printf
+func (o *AppOptions) println(v ...any) {
+	if o != nil && o.out != nil {
+		color.Fprintln(o.out, v...)
+	} else {
+		color.Println(v...)
+	}
+}
+
+// tips print the message with the theme name as title to the app output. like color.Error.Tips
+func (o *AppOptions) tips(theme *color.Theme, msg string) {
+	o.printf("%s%s\n", color.RenderString(theme.Code(), strings.ToUpper(theme.Name)+": "), msg)
+}
+
+// errOutWriter write to the error output of the app options, it is resolved on write.
+// so it follows the App.SetOutput after created.
+type errOutWriter struct {
+	opts *AppOptions
+}
+
+// Write implements io.Writer
+func (w errOutWriter) Write(p []byte) (int, error) { return w.opts.ErrOut().Write(p) }
+
+// SetInput set the input reader for the app, the Confirm and the option Question prompts
+// read from it. nil for the default os.Stdin
+func (app *App) SetInput(in io.Reader) { app.opts.SetInput(in) }
+
+// SetOutput set the output writers for the app. the help, version, error tips, logs and the
+// Command.Out/ErrOut writes to them. nil for the defaults(os.Stdout, os.Stderr).
+//
+// Usage:
+//
+//	buf := new(bytes.Buffer)
+//	app.SetOutput(buf, buf)
+func (app *App) SetOutput(out, errOut io.Writer) { app.opts.SetOutput(out, errOut) }
+
+// In get the input reader of the command. see App.SetInput
+func (c *Command) In() io.Reader { return c.ownerOpts().In() }
+
+// Out get the output writer of the command, print the command outputs to it. see App.SetOutput
+//
+// Usage:
+//
+//	fmt.Fprintln(c.Out(), "hello")
+func (c *Command) Out() io.Writer { return c.ownerOpts().Out() }
+
+// ErrOut get the error output writer of the command. see App.SetOutput
+func (c *Command) ErrOut() io.Writer { return c.ownerOpts().ErrOut() }
@@ -1,12 +1,17 @@
 package gcli
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"os"
 	"regexp"
 	"strings"
 
 	"github.com/gookit/color"
 	"github.com/gookit/gcli/v3/internal/helper"
+	"github.com/gookit/goutil/cflag"
+	"github.com/gookit/goutil/cliutil/cmdline"
 	"github.com/gookit/goutil/x/goinfo"
 )
 
@@ -22,36 +27,104 @@ var level2color = map[VerbLevel]color.Color{
 	VerbCrazy: color.FgMagenta,
 }
 
-// Debugf print log message
+// Debugf print log message, by the process default verbose level and logger.
+//
+// NOTE: it is not resolved through an app, use App.Debugf or Command.Debugf for that.
 func Debugf(format string, v ...any) {
-	logf(VerbDebug, format, v...)
+	logf(nil, VerbDebug, format, v...)
 }
 
-// Logf print log message
+// Logf print log message, by the process default verbose level and logger.
+//
+// NOTE: it is not resolved through an app, use App.Logf or Command.Logf for that.
 func Logf(level VerbLevel, format string, v ...any) {
-	logf(level, format, v...)
+	logf(nil, level, format, v...)
 }
 
-// print log message
-func logf(level VerbLevel, format string, v ...any) {
-	if gOpts.Verbose < level {
+// print log message by the verbose level and logger of the app options. nil for the process defaults.
+func logf(opts *AppOptions, level VerbLevel, format string, v ...any) {
+	verb, logger := gOpts.Verbose, gOpts.logger
+	if opts != nil {
+		verb, logger = opts.Verbose(), opts.Logger()
+	}
+	if !logEnabled(logger, verb, level) {
 		return
 	}
 
-	name := level2color[level].Render(level.Upper())
 	logAt := goinfo.GetCallerInfo(3)
+	writeLog(opts, logger, level, logAt, fmt.Sprintf(format, v...))
+}
 
-	color.Printf("GCLI: [%s] [<gray>%s</>] %s \n", name, logAt, fmt.Sprintf(format, v...))
+// debugf print debug message by the verbose level and logger of the app options.
+// nil options for the process defaults.
+func (o *AppOptions) debugf(format string, v ...any) {
+	logf(o, VerbDebug, format, v...)
 }
 
-func defaultErrHandler(ctx *HookCtx) (stop bool) {
-	if errV := ctx.Get("err"); errV != nil {
-		if err, ok := errV.(error); ok {
-			color.Error.Tips(err.Error())
-			// fmt.Println(color.Red.Render("ERROR:"), err.Error())
+// logEnabled check the log level is enabled. filter by the slog logger if it is set(the level of the
+// logger handler decides), otherwise by the verbose level.
+func logEnabled(logger *slog.Logger, verb, level VerbLevel) bool {
+	if logger != nil {
+		return logger.Enabled(context.Background(), VerbToSlog(level))
+	}
+	return verb >= level
+}
+
+// writeLog write the log message to the slog logger if not nil, otherwise print the colored text.
+// logAt is the caller info, the warning without it will be printed to the error output of the app.
+func writeLog(opts *AppOptions, logger *slog.Logger, level VerbLevel, logAt, msg string) {
+	if logger != nil {
+		var attrs []any
+		if logAt != "" {
+			attrs = append(attrs, "caller", logAt)
 		}
+		logger.Log(context.Background(), VerbToSlog(level), color.ClearTag(msg), attrs...)
+		return
 	}
 
+	name := level2color[level].Render(level.Upper())
+	if logAt == "" {
+		color.Fprintf(opts.ErrOut(), "GCLI: [%s] %s\n", name, msg)
+	} else {
+		opts.printf("GCLI: [%s] [<gray>%s</>] %s \n", name, logAt, msg)
+	}
+}
+
+// warnOnce print a warning log message to stderr(or the slog logger), only once for the key in the app.
+// the printed keys are recorded on the app options, see AppOptions.ResetWarnings
+//
+// NOTE: it is not limited by the verbose level(except VerbQuiet), so users can always see it.
+// eg: the deprecated command or option is used. if a slog logger is set, it is filtered by the logger.
+func warnOnce(opts *AppOptions, key, format string, v ...any) {
+	logger := opts.Logger()
+	if logger != nil {
+		if !logger.Enabled(context.Background(), VerbToSlog(VerbWarn)) {
+			return
+		}
+	} else if opts.Verbose() == VerbQuiet {
+		return
+	}
+
+	if opts.markWarned(key) {
+		writeLog(opts, logger, VerbWarn, "", fmt.Sprintf(format, v...))
+	}
+}
+
+// warnDeprecatedOpt print the deprecated option warning once. path is the command path or app name.
+func warnDeprecatedOpt(opts *AppOptions, path, name, msg string) {
+	warnOnce(opts, "opt:"+path+":"+name, "option '%s' is deprecated, %s", cflag.AddPrefix(name), msg)
+}
+
+func defaultErrHandler(ctx *HookCtx) (stop bool) {
+	if err := ctx.Err(); err != nil {
+		var opts *AppOptions
+		if ctx.Cmd != nil {
+			opts = ctx.Cmd.ownerOpts()
+		} else if ctx.App != nil {
+			opts = ctx.App.opts
+		}
+		opts.tips(color.Error, err.Error())
+	}
 	return
 }
 
@@ -140,6 +213,77 @@ func strictFormatArgs(args []string) (fmtArgs []string) {
 	return fmtArgs
 }
 
+// MaxArgFileDepth the max nesting depth of the '@file' argument files. see base.ArgFiles
+var MaxArgFileDepth = 8
+
+// expandArgFiles expand '@file' argument files(response files) in args. like javac/gcc.
+//
+// File format:
+//
+//   - args split by whitespace, one or more per line. quotes are allowed in a line: `--msg "hello world"`
+//   - empty line and line starts with '#' are ignored
+//   - nested '@file' are expanded, max depth is MaxArgFileDepth
+//
+// Special args:
+//
+//   - '@@text' is an escaped literal arg '@text'
+//   - '@' only is kept as is
+//   - args after '--' are kept as is
+//
+// opts is the options of the owning app, for print the debug log.
+func expandArgFiles(opts *AppOptions, args []string, depth int) ([]string, error) {
+	if depth > MaxArgFileDepth {
+		return nil, fmt.Errorf("argument files nested too deep(max depth %d)", MaxArgFileDepth)
+	}
+
+	fmtArgs := make([]string, 0, len(args))
+	for i, arg := range args {
+		if arg == "--" {
+			return append(fmtArgs, args[i:]...), nil
+		}
+		if len(arg) < 2 || arg[0] != '@' {
+			fmtArgs = append(fmtArgs, arg)
+			continue
+		}
+
+		// escaped, eg: '@@text' -> '@text'
+		if arg[1] == '@' {
+			fmtArgs = append(fmtArgs, arg[1:])
+			continue
+		}
+
+		fileArgs, err := readArgFile(arg[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		opts.debugf("expand argument file %s to args: %v", arg, fileArgs)
+		if fileArgs, err = expandArgFiles(opts, fileArgs, depth+1); err != nil {
+			return nil, err
+		}
+		fmtArgs = append(fmtArgs, fileArgs...)
+	}
+	return fmtArgs, nil
+}
+
+// readArgFile read and parse args from an argument file.
+func readArgFile(file string) ([]string, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read argument file failed: %s", err.Error())
+	}
+
+	var args []string
+	for _, line := range strings.Split(string(bs), "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\t", " "))
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		args = append(args, cmdline.NewParser(line).Parse()...)
+	}
+	return args, nil
+}
+
 // flags parser is flag#FlagSet.Parse(), so:
 // - if args like: "arg0 arg1 --opt", will parse fail
 // - if args convert to: "--opt arg0 arg1", can correctly parse
@@ -1,6 +1,8 @@
 package gcli_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gookit/gcli/v3"
@@ -29,3 +31,79 @@ func Test_strictFormatArgs(t *testing.T) {
 	is.Eq(true, t2)
 	is.Eq(false, t3)
 }
+
+func TestApp_Run_argFiles(t *testing.T) {
+	is := assert.New(t)
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested.txt")
+	is.NoErr(os.WriteFile(nested, []byte("--tag v2\n"), 0644))
+	argFile := filepath.Join(dir, "args.txt")
+	is.NoErr(os.WriteFile(argFile, []byte(
+		"# build options\n--name \"hello world\"\n\n\t--tag v1 @"+nested+"\narg0\n",
+	), 0644))
+
+	var name string
+	var tags gcli.Strings
+	var gotArgs []string
+	newApp := func() *gcli.App {
+		app := gcli.NewApp(gcli.NotExitOnEnd())
+		app.ArgFiles = true
+		app.Add(&gcli.Command{
+			Name: "build",
+			Config: func(c *gcli.Command) {
+				c.StrOpt(&name, "name", "n", "", "the name")
+				c.VarOpt(&tags, "tag", "", "the tags")
+				c.AddArg("files", "the input files", false, true)
+			},
+			Func: func(c *gcli.Command, args []string) error {
+				gotArgs = c.Arg("files").Strings()
+				return nil
+			},
+		})
+		return app
+	}
+
+	t.Run("expand files", func(t *testing.T) {
+		tags = nil
+		app := newApp()
+		is.Eq(0, app.Run([]string{"build", "@" + argFile, "@@arg1", "--", "@arg2"}))
+		is.NoErr(app.Errors())
+		is.Eq("hello world", name)
+		is.Eq([]string{"v1", "v2"}, tags.Strings())
+		is.Eq([]string{"arg0", "@arg1", "--", "@arg2"}, gotArgs)
+	})
+
+	t.Run("file not exists", func(t *testing.T) {
+		app := newApp()
+		is.Eq(2, app.Run([]string{"build", "@not-exists.txt"}))
+		is.ErrSubMsg(app.Errors(), "read argument file failed")
+	})
+
+	t.Run("nested too deep", func(t *testing.T) {
+		self := filepath.Join(dir, "self.txt")
+		is.NoErr(os.WriteFile(self, []byte("@"+self), 0644))
+		app := newApp()
+		is.Eq(2, app.Run([]string{"build", "@" + self}))
+		is.ErrSubMsg(app.Errors(), "argument files nested too deep")
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		app := newApp()
+		app.ArgFiles = false
+		is.Eq(0, app.Run([]string{"build", "@" + nested}))
+		is.Eq([]string{"@" + nested}, gotArgs)
+	})
+
+	t.Run("standalone command", func(t *testing.T) {
+		name = ""
+		cmd := gcli.NewCommand("build", "desc", func(c *gcli.Command) {
+			c.StrOpt(&name, "name", "n", "", "the name")
+			c.VarOpt(&tags, "tag", "", "the tags")
+			c.AddArg("files", "the input files", false, true)
+		})
+		cmd.ArgFiles = true
+		cmd.Func = func(c *gcli.Command, args []string) error { return nil }
+		is.NoErr(cmd.Run([]string{"@" + argFile}))
+		is.Eq("hello world", name)
+	})
+}
@@ -0,0 +1,216 @@
+package gcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/gookit/goutil/strutil"
+)
+
+// build metadata, can be injected by ldflags on build. eg:
+//
+//	go build -ldflags "-X 'github.com/gookit/gcli/v3.BuildVersion=v1.2.0' \
+//		-X 'github.com/gookit/gcli/v3.BuildCommit=abc1234' \
+//		-X 'github.com/gookit/gcli/v3.BuildTime=2024-05-01T10:00:00Z'"
+//
+// TIP: if not set, will use the VCS info from debug.ReadBuildInfo
+var (
+	// BuildVersion the app version, will be the default App.Version
+	BuildVersion string
+	// BuildCommit the VCS revision
+	BuildCommit string
+	// BuildTime the build time
+	BuildTime string
+)
+
+// defaultAppVersion the default App.Version on not set
+const defaultAppVersion = "0.1.0-dev"
+
+// readBuildInfo for mock on tests
+var readBuildInfo = debug.ReadBuildInfo
+
+// ModuleDep a dependency module of the app
+type ModuleDep struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	// Replace the replacement module path, if replaced.
+	Replace string `json:"replace,omitempty"`
+}
+
+// BuildInfo the app version and build metadata
+type BuildInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Commit the VCS revision
+	Commit string `json:"commit,omitempty"`
+	// Dirty the VCS working tree has local modifications on build
+	Dirty bool `json:"dirty"`
+	// CommitTime the VCS commit time(vcs.time)
+	CommitTime string `json:"commit_time,omitempty"`
+	// BuildTime the build time, only set by the ldflags(see BuildTime)
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+	// Platform eg: "linux/amd64"
+	Platform string `json:"platform"`
+	// Module the main module path
+	Module string      `json:"module,omitempty"`
+	Deps   []ModuleDep `json:"deps,omitempty"`
+}
+
+// BuildInfo collect the app version and build metadata.
+//
+// The ldflags values(BuildCommit, BuildTime) take precedence over the VCS info.
+// The main module version is used on the App.Version is not set or is the default "0.1.0-dev".
+// eg: installed by `go install example.com/myapp@v1.2.0`
+func (app *App) BuildInfo() *BuildInfo {
+	bi := &BuildInfo{
+		Name:      app.Name,
+		Version:   app.Version,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if info, ok := readBuildInfo(); ok && info != nil {
+		bi.Module = info.Main.Path
+		modVer := info.Main.Version
+		if (bi.Version == "" || bi.Version == defaultAppVersion) && modVer != "" && modVer != "(devel)" {
+			bi.Version = modVer
+		}
+		if info.GoVersion != "" {
+			bi.GoVersion = info.GoVersion
+		}
+
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				bi.Commit = s.Value
+			case "vcs.time":
+				bi.CommitTime = s.Value
+			case "vcs.modified":
+				bi.Dirty = s.Value == "true"
+			}
+		}
+
+		for _, dep := range info.Deps {
+			md := ModuleDep{Path: dep.Path, Version: dep.Version}
+			if dep.Replace != nil {
+				md.Replace = dep.Replace.Path
+				if dep.Replace.Version != "" {
+					md.Version = dep.Replace.Version
+				}
+			}
+			bi.Deps = append(bi.Deps, md)
+		}
+	}
+
+	if BuildCommit != "" {
+		bi.Commit = BuildCommit
+	}
+	if BuildTime != "" {
+		bi.BuildTime = BuildTime
+	}
+	return bi
+}
+
+// ShortCommit get the short(7 chars) commit hash
+func (bi *BuildInfo) ShortCommit() string {
+	if len(bi.Commit) > 7 {
+		return bi.Commit[:7]
+	}
+	return bi.Commit
+}
+
+// Short version line. eg: "1.2.0 (abc1234-dirty, 2024-05-01T10:00:00Z)"
+//
+// the time is the BuildTime, or the CommitTime if not set.
+func (bi *BuildInfo) Short() string {
+	var extra []string
+	if commit := bi.ShortCommit(); commit != "" {
+		if bi.Dirty {
+			commit += "-dirty"
+		}
+		extra = append(extra, commit)
+	}
+	if bi.BuildTime != "" {
+		extra = append(extra, bi.BuildTime)
+	} else if bi.CommitTime != "" {
+		extra = append(extra, bi.CommitTime)
+	}
+
+	if len(extra) == 0 {
+		return bi.Version
+	}
+	return bi.Version + " (" + strings.Join(extra, ", ") + ")"
+}
+
+// String full version info text, without the dependency modules.
+func (bi *BuildInfo) String() string { return bi.Format(false) }
+
+// Format full version info text. withDeps for list the dependency modules.
+func (bi *BuildInfo) Format(withDeps bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s version %s\n", bi.Name, bi.Version))
+
+	writeField := func(name, val string) {
+		if val != "" {
+			sb.WriteString(fmt.Sprintf("  %-12s %s\n", name+":", val))
+		}
+	}
+
+	commit := bi.Commit
+	if commit != "" && bi.Dirty {
+		commit += " (dirty)"
+	}
+	writeField("Commit", commit)
+	writeField("Commit time", bi.CommitTime)
+	writeField("Build time", bi.BuildTime)
+	writeField("Go version", bi.GoVersion)
+	writeField("Platform", bi.Platform)
+	writeField("Module", bi.Module)
+
+	if withDeps && len(bi.Deps) > 0 {
+		sb.WriteString("  Deps:\n")
+		for _, dep := range bi.Deps {
+			line := dep.Path + " " + dep.Version
+			if dep.Replace != "" {
+				line += " => " + dep.Replace
+			}
+			sb.WriteString("    " + line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// JSON encode the build info to indented JSON string.
+func (bi *BuildInfo) JSON() string {
+	bs, _ := json.MarshalIndent(bi, "", "  ")
+	return string(bs)
+}
+
+// RequireMinVersion check the app version is greater than or equal to the min version.
+//
+// Usage:
+//
+//	if err := app.RequireMinVersion("1.2.0"); err != nil {
+//		return err
+//	}
+func (app *App) RequireMinVersion(minVer string) error {
+	return CheckMinVersion(app.Version, minVer)
+}
+
+// CheckMinVersion check the version is greater than or equal to the min version.
+//
+// TIP: plugin command can check the host app version by the env PluginEnvVersion.
+func CheckMinVersion(version, minVer string) error {
+	if version == "" || minVer == "" {
+		return fmt.Errorf("check version: version and min version cannot be empty")
+	}
+
+	if !strutil.VersionCompare(version, minVer, ">=") {
+		return fmt.Errorf("require version >= %s, but current version is %s", minVer, version)
+	}
+	return nil
+}